@@ -46,9 +46,19 @@ const (
 )
 
 func bootstrapperNew() {
-		bootstrapper = new(appBootstrapper)
-		bootstrapper.Name = AppBootstrapperClass
-		bootstrapper.Lists = make(map[string]Bootstrapper)
+		bootstrapper = newAppBootstrapper()
+}
+
+// 创建独立的引导器 (非全局), 供 Supports.NewApp 使用
+func NewAppBootstrapper() BootstrapProvider {
+		return newAppBootstrapper()
+}
+
+func newAppBootstrapper() *appBootstrapper {
+		var provider = new(appBootstrapper)
+		provider.Name = AppBootstrapperClass
+		provider.Lists = make(map[string]Bootstrapper)
+		return provider
 }
 
 // Bootstrapper
@@ -138,7 +148,7 @@ func (this *appBootstrapper) Factory(app Contracts.ApplicationContainer) interfa
 }
 
 func (this *appBootstrapper) Constructor() interface{} {
-		return AppBootstrapperOf()
+		return NewAppBootstrapper()
 }
 
 func (this *appBootstrapper) Add(items ...Bootstrapper) bool {
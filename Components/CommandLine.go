@@ -1,7 +1,9 @@
 package Components
 
 import (
+		"fmt"
 		"github.com/webGameLinux/kits/Contracts"
+		"io"
 		"sync"
 )
 
@@ -26,6 +28,14 @@ type Commander interface {
 		Option(string) string
 		Options(string) []string
 		GetOptions() map[string]*OptionArg
+		Add(name string, description string, handler CommandHandler)
+		Lookup(string) *Command
+		Commands() []*Command
+		Requested() (*Command, []string)
+		Called() (string, []string)
+		SetOptionLookup(OptionLookup)
+		Define(signature string, description string, handler ConsoleHandler) error
+		Call(name string, args []string, out io.Writer) int
 }
 
 // 参数值
@@ -42,6 +52,11 @@ type CommandLineArgsProviderImpl struct {
 		app     Contracts.ApplicationContainer
 }
 
+const (
+		CommandLineProviderClass = "CommandLine"
+		HelpCommand              = "help"
+)
+
 var (
 		commanderInstanceLock sync.Once
 		commander             *CommandLineArgsProviderImpl
@@ -58,6 +73,11 @@ func commandLineProviderNew() {
 		commander = new(CommandLineArgsProviderImpl)
 }
 
+// 创建独立的命令行服务 (非全局)
+func NewCommandLineArgsProvider() CommandLineArgsProvider {
+		return new(CommandLineArgsProviderImpl)
+}
+
 func (this *CommandLineArgsProviderImpl) GetClazz() Contracts.ClazzInterface {
 		if this.clazz == nil {
 				this.clazz = ClazzOf(this)
@@ -80,9 +100,30 @@ func (this *CommandLineArgsProviderImpl) GetSupportBean() Contracts.SupportInter
 func (this *CommandLineArgsProviderImpl) Register() {
 		// 解析commander
 		this.command.Init()
+		// 命令名前的全局选项, NewApp 时 由应用选项注入
+		if lookup, ok := this.app.GetProfile(Contracts.AppCommandOptions).(OptionLookup); ok {
+				this.command.SetOptionLookup(lookup)
+		}
+		_ = this.command.Define(HelpCommand+" {command? : 命令名}", "display help for a command", this.help)
 		this.app.Bind(this.GetClazz().String(), this.command)
 }
 
+// help 命令, 无参数时 输出命令列表
+func (this *CommandLineArgsProviderImpl) help(input *ConsoleInput, out io.Writer) int {
+		var name = input.Argument("command")
+		if name == "" {
+				fmt.Fprint(out, this.command.Help())
+				return ExitSuccess
+		}
+		command := this.command.Lookup(name)
+		if command == nil {
+				fmt.Fprintf(out, "command %q is not defined\n", name)
+				return ExitUsage
+		}
+		fmt.Fprint(out, command.Help())
+		return ExitSuccess
+}
+
 func (this *CommandLineArgsProviderImpl) Boot() {
 		// 解析运行 commander 参数
 		commander := this.app.Get(this.String())
@@ -123,9 +164,18 @@ func (this *CommandLineArgsProviderImpl) Factory(app Contracts.ApplicationContai
 }
 
 func (this *CommandLineArgsProviderImpl) Constructor() interface{} {
-		return CommandLineArgsProviderOf()
+		return NewCommandLineArgsProvider()
 }
 
 func (this *CommandLineArgsProviderImpl) String() string {
-		return "CommandLine"
+		return CommandLineProviderClass
+}
+
+// 是否执行已注册的控制台命令, 执行命令时 服务不启动监听
+func RunningCommand(app Contracts.ApplicationContainer) bool {
+		if commander, ok := app.Get(CommandLineProviderClass).(Commander); ok {
+				command, _ := commander.Requested()
+				return command != nil
+		}
+		return false
 }
@@ -1,6 +1,32 @@
 package Components
 
-import "os"
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 控制台命令处理, 返回退出码
+type CommandHandler func(args []string, out io.Writer) int
+
+// 控制台命令
+type Command struct {
+	Name        string
+	Description string
+	Signature   *CommandSignature
+	Handler     CommandHandler
+}
+
+// 命令帮助, 签名命令 包含参数及选项说明
+func (this *Command) Help() string {
+	if this.Signature != nil {
+		return this.Signature.Help(this.Description)
+	}
+	return fmt.Sprintf("Description:\n  %s\n\nUsage:\n  %s [args]\n", this.Description, this.Name)
+}
 
 // 命令行结果体
 type CommanderImpl struct {
@@ -9,6 +35,9 @@ type CommanderImpl struct {
 	options    map[string]string
 	helpMenu   string
 	optionArgs map[string]*OptionArg
+	commands   map[string]*Command
+	lookup     OptionLookup
+	mutex      sync.RWMutex
 }
 
 func (this *CommanderImpl) Init() {
@@ -36,7 +65,170 @@ func (this *CommanderImpl) Argc() int {
 }
 
 func (this *CommanderImpl) Help() string {
-	return this.helpMenu
+	var commands = this.Commands()
+	if len(commands) == 0 {
+		return this.helpMenu
+	}
+	var buf strings.Builder
+	buf.WriteString(this.helpMenu)
+	buf.WriteString("  commands :\n")
+	for _, command := range commands {
+		buf.WriteString(fmt.Sprintf("    %-20s %s\n", command.Name, command.Description))
+	}
+	return buf.String()
+}
+
+// 注册命令, 同名覆盖
+func (this *CommanderImpl) Add(name string, description string, handler CommandHandler) {
+	if name == "" || handler == nil {
+		return
+	}
+	this.register(&Command{Name: name, Description: description, Handler: handler})
+}
+
+// 按签名注册命令, 参数校验通过后 调用 handler, 校验失败 返回 ExitUsage
+func (this *CommanderImpl) Define(signature string, description string, handler ConsoleHandler) error {
+	sig, err := ParseSignature(signature)
+	if err != nil {
+		return err
+	}
+	if handler == nil {
+		return fmt.Errorf("%s: handler is nil", sig.Name)
+	}
+	this.register(&Command{Name: sig.Name, Description: description, Signature: sig, Handler: func(args []string, out io.Writer) int {
+		input, err := sig.Parse(args)
+		if err != nil {
+			fmt.Fprintf(out, "%v\n\n%s", err, sig.Usage())
+			return ExitUsage
+		}
+		return handler(input, out)
+	}})
+	return nil
+}
+
+func (this *CommanderImpl) register(command *Command) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if this.commands == nil {
+		this.commands = make(map[string]*Command)
+	}
+	this.commands[command.Name] = command
+}
+
+// 执行命令, -h/--help 输出命令帮助, 未注册 输出命令列表, panic 返回 ExitFailure
+func (this *CommanderImpl) Call(name string, args []string, out io.Writer) (code int) {
+	var command = this.Lookup(name)
+	if command == nil {
+		fmt.Fprintf(out, "command %q is not defined\n\n%s", name, this.Help())
+		return ExitUsage
+	}
+	if helpRequested(args) {
+		fmt.Fprint(out, command.Help())
+		return ExitSuccess
+	}
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Fprintf(out, "command %s failed: %v\n", name, err)
+			code = ExitFailure
+		}
+	}()
+	return command.Handler(args, out)
+}
+
+func helpRequested(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "-h", "--help":
+			return true
+		}
+	}
+	return false
+}
+
+func (this *CommanderImpl) Lookup(name string) *Command {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return this.commands[name]
+}
+
+// 按名称排序的命令
+func (this *CommanderImpl) Commands() []*Command {
+	this.mutex.RLock()
+	var commands = make([]*Command, 0, len(this.commands))
+	for _, command := range this.commands {
+		commands = append(commands, command)
+	}
+	this.mutex.RUnlock()
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+	return commands
+}
+
+// 首个参数为已注册命令时 返回命令及其参数
+func (this *CommanderImpl) Requested() (*Command, []string) {
+	name, args := this.Called()
+	if name == "" {
+		return nil, nil
+	}
+	if command := this.Lookup(name); command != nil {
+		return command, args
+	}
+	return nil, nil
+}
+
+// 命令行请求的命令名 及其参数
+func (this *CommanderImpl) Called() (string, []string) {
+	if i := CommandIndex(this.args, this.lookup); i > 0 {
+		return this.args[i], this.args[i+1:]
+	}
+	return "", nil
+}
+
+// 设置全局选项查找, 命令名前的全局选项 据此跳过
+func (this *CommanderImpl) SetOptionLookup(lookup OptionLookup) {
+	this.lookup = lookup
+}
+
+// 全局选项查找: 返回是否为已知全局选项 及是否需要取值
+type OptionLookup func(flag string) (known bool, valued bool)
+
+// 命令名位置: 跳过已知全局选项 后的首个非选项参数, 遇到未知选项 或无命令时 返回 0
+func CommandIndex(args []string, lookup OptionLookup) int {
+	for i := 1; i < len(args); i++ {
+		var arg = args[i]
+		if arg == "--" {
+			return 0
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return i
+		}
+		if lookup == nil {
+			return 0
+		}
+		var flag, hasValue = arg, false
+		if j := strings.Index(arg, "="); j > 0 {
+			flag, hasValue = arg[:j], true
+		}
+		known, valued := lookup(flag)
+		if !known {
+			return 0
+		}
+		if valued && !hasValue {
+			i++
+		}
+	}
+	return 0
+}
+
+// 命令名, 无命令时 返回空
+func CommandName(args []string, lookup OptionLookup) string {
+	if i := CommandIndex(args, lookup); i > 0 {
+		return args[i]
+	}
+	return ""
 }
 
 func (this *CommanderImpl) Title() string {
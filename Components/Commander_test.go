@@ -0,0 +1,95 @@
+package Components
+
+import (
+		"bytes"
+		. "github.com/smartystreets/goconvey/convey"
+		"io"
+		"testing"
+)
+
+func TestCommanderCommands(t *testing.T) {
+		var (
+				commander = new(CommanderImpl)
+				handler   = func(args []string, out io.Writer) int {
+						_, _ = out.Write([]byte("args:" + args[0]))
+						return 2
+				}
+		)
+		commander.Add("user:create", "create user", handler)
+		commander.Add("app:profiles", "dump profiles", handler)
+		commander.Add("", "ignored", handler)
+		Convey("Commander Commands Test", t, func() {
+				commands := commander.Commands()
+				So(len(commands), ShouldEqual, 2)
+				So(commands[0].Name, ShouldEqual, "app:profiles")
+				So(commander.Help(), ShouldContainSubstring, "user:create")
+
+				commander.args = []string{"kits", "--mode=dev"}
+				command, _ := commander.Requested()
+				So(command, ShouldBeNil)
+
+				commander.args = []string{"kits", "user:create", "tom"}
+				command, args := commander.Requested()
+				So(command, ShouldNotBeNil)
+				var buf bytes.Buffer
+				So(command.Handler(args, &buf), ShouldEqual, 2)
+				So(buf.String(), ShouldEqual, "args:tom")
+
+				commander.args = []string{"kits", "user:delete", "tom"}
+				command, _ = commander.Requested()
+				So(command, ShouldBeNil)
+
+				// 命令名前的全局选项
+				commander.SetOptionLookup(func(flag string) (bool, bool) {
+						return flag == "--mode", true
+				})
+				commander.args = []string{"kits", "--mode", "dev", "user:create", "tom"}
+				command, args = commander.Requested()
+				So(command, ShouldNotBeNil)
+				So(args, ShouldResemble, []string{"tom"})
+
+				commander.args = []string{"kits", "--file", "a", "user:create"}
+				command, _ = commander.Requested()
+				So(command, ShouldBeNil)
+		})
+}
+
+func TestCommanderDefine(t *testing.T) {
+		var commander = new(CommanderImpl)
+		Convey("Commander Define Test", t, func() {
+				So(commander.Define("user:create {name?} {role}", "create user", nil), ShouldNotBeNil)
+				err := commander.Define("user:create {name} {--admin}", "create user", func(input *ConsoleInput, out io.Writer) int {
+						if input.Bool("admin") {
+								panic("admin denied")
+						}
+						_, _ = out.Write([]byte("created:" + input.Argument("name")))
+						return ExitSuccess
+				})
+				So(err, ShouldBeNil)
+
+				var buf bytes.Buffer
+				So(commander.Call("user:create", []string{"tom"}, &buf), ShouldEqual, ExitSuccess)
+				So(buf.String(), ShouldEqual, "created:tom")
+
+				buf.Reset()
+				So(commander.Call("user:create", nil, &buf), ShouldEqual, ExitUsage)
+				So(buf.String(), ShouldContainSubstring, "missing: name")
+
+				buf.Reset()
+				So(commander.Call("user:create", []string{"--help"}, &buf), ShouldEqual, ExitSuccess)
+				So(buf.String(), ShouldContainSubstring, "Usage:\n  user:create [options] [--] <name>")
+
+				buf.Reset()
+				So(commander.Call("user:create", []string{"tom", "--admin"}, &buf), ShouldEqual, ExitFailure)
+				So(buf.String(), ShouldContainSubstring, "admin denied")
+
+				buf.Reset()
+				So(commander.Call("user:delete", nil, &buf), ShouldEqual, ExitUsage)
+				So(buf.String(), ShouldContainSubstring, "user:create")
+
+				commander.args = []string{"kits", "user:delete", "tom"}
+				name, args := commander.Called()
+				So(name, ShouldEqual, "user:delete")
+				So(args, ShouldResemble, []string{"tom"})
+		})
+}
@@ -8,6 +8,7 @@ import (
 		"io"
 		"os"
 		"path/filepath"
+		"strconv"
 		"strings"
 		"sync"
 )
@@ -171,7 +172,9 @@ func (this *ConfigureProviderImpl) getEnvProvider() EnvironmentProvider {
 		if provider, ok := env.(EnvironmentProvider); ok {
 				return provider
 		}
-		return EnvironmentProviderOf()
+		var provider = newEnvironmentProvider()
+		provider.Init(this.app)
+		return provider
 }
 
 func (this *ConfigureProviderImpl) IntArray(key string, defaults ...[]int) []int {
@@ -202,6 +205,11 @@ func (this *ConfigureProviderImpl) HashMap(key string, defaults ...*HashMapperSt
 		return this.config().HashMap(key, defaults...)
 }
 
+// 配置依赖环境变量
+func (this *ConfigureProviderImpl) DependsOn() []string {
+		return []string{EnvironmentProviderClass}
+}
+
 func (this *ConfigureProviderImpl) Factory(app Contracts.ApplicationContainer) interface{} {
 		this.Init(app)
 		return this.instance
@@ -305,7 +313,7 @@ func (this *ConfigureProviderImpl) getScopesByArr(root string, keys []string) (m
 }
 
 func (this *ConfigureProviderImpl) Constructor() interface{} {
-		return ConfigureProviderOf()
+		return NewConfigureProvider()
 }
 
 func (this *ConfigureProviderImpl) String() string {
@@ -325,6 +333,15 @@ func (this *ConfigureProviderImpl) Register() {
 }
 
 func (this *ConfigureProviderImpl) Boot() {
+		this.load()
+}
+
+// 重新执行配置加载器
+func (this *ConfigureProviderImpl) Reload() {
+		this.load()
+}
+
+func (this *ConfigureProviderImpl) load() {
 		configure := this.app.Get(ConfigurationAlias)
 		if cnf, ok := configure.(Configuration); ok {
 				fn := this.app.Get(ConfigureLoaderName)
@@ -334,6 +351,18 @@ func (this *ConfigureProviderImpl) Boot() {
 				if loader, ok := fn.(func(Configuration, Contracts.ApplicationContainer)); ok {
 						loader(cnf, this.app)
 				}
+				this.override(cnf)
+		}
+}
+
+// 命令行 --set/-D 覆盖配置, 在加载器之后执行 优先级最高
+func (this *ConfigureProviderImpl) override(cnf Configuration) {
+		overrides, ok := this.app.GetProfile(Contracts.AppConfigOverrides).(map[string]string)
+		if !ok {
+				return
+		}
+		for key, value := range overrides {
+				cnf.Add(key, value)
 		}
 }
 
@@ -357,8 +386,18 @@ func ConfigureProviderOf() ConfigureProvider {
 }
 
 func configureProviderNew() {
-		configureProvider = new(ConfigureProviderImpl)
-		configureProvider.Name = ConfigureProviderClass
+		configureProvider = newConfigureProvider()
+}
+
+// 创建独立的配置服务 (非全局)
+func NewConfigureProvider() ConfigureProvider {
+		return newConfigureProvider()
+}
+
+func newConfigureProvider() *ConfigureProviderImpl {
+		var provider = new(ConfigureProviderImpl)
+		provider.Name = ConfigureProviderClass
+		return provider
 }
 
 func ConfigureOf() Configuration {
@@ -372,10 +411,14 @@ func (this *Configure) Int(key string, defaults ...int) int {
 				defaults = append(defaults, 0)
 		}
 		v := this.Any(key, defaults[0])
-		// todo string to int
 		if n, ok := v.(int); ok {
 				return n
 		}
+		if str, ok := v.(string); ok {
+				if n, err := strconv.Atoi(strings.TrimSpace(str)); err == nil {
+						return n
+				}
+		}
 		return defaults[0]
 }
 
@@ -857,9 +900,6 @@ func getFileConfigure(config interface{}, loader *Libs.ConfigureViperLoader, pat
 		if !filepath.IsAbs(fs) {
 				fs, _ = filepath.Abs(fs)
 		}
-		if Debug() {
-				LoggerProviderOf().Info("config loader file :" + fs)
-		}
 		loader.Mapper.SetConfigFile(fs)
 		loader.Mapper.SetConfigType(ext)
 		if err := loader.Mapper.ReadInConfig(); err != nil {
@@ -2,6 +2,7 @@ package Components
 
 import (
 		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
 		"testing"
 )
 
@@ -13,3 +14,43 @@ func TestConfigureOf(t *testing.T) {
 				So(len(cnf.IntArray("app.arr")), ShouldEqual, len([]int{1, 2, 3, 67, 0, 0}))
 		})
 }
+
+type configApp struct {
+		Contracts.ApplicationContainer
+		binds    map[string]interface{}
+		profiles map[string]interface{}
+}
+
+func (this *configApp) Get(id string) interface{} {
+		return this.binds[id]
+}
+
+func (this *configApp) GetProfile(key string) interface{} {
+		return this.profiles[key]
+}
+
+func TestConfigureProviderOverride(t *testing.T) {
+		var (
+				provider = newConfigureProvider()
+				app      = &configApp{binds: make(map[string]interface{}), profiles: make(map[string]interface{})}
+		)
+		provider.Init(app)
+		var cnf = provider.instance.(Configuration)
+		app.binds[ConfigurationAlias] = cnf
+		app.binds[ConfigAlias] = cnf
+		app.binds[ConfigureLoaderName] = func(config Configuration, app Contracts.ApplicationContainer) {
+				config.Add("http.port", 8080)
+				config.Add("redis.addr", "127.0.0.1:6379")
+		}
+		app.profiles[Contracts.AppConfigOverrides] = map[string]string{"http.port": "9090", "game.zone": "2"}
+		Convey("Configure Provider Override Test", t, func() {
+				provider.Boot()
+				So(provider.Get("http.port"), ShouldEqual, "9090")
+				So(provider.Int("http.port"), ShouldEqual, 9090)
+				So(provider.Int("game.zone"), ShouldEqual, 2)
+				So(provider.Get("redis.addr"), ShouldEqual, "127.0.0.1:6379")
+
+				provider.Reload()
+				So(provider.Get("http.port"), ShouldEqual, "9090")
+		})
+}
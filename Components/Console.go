@@ -0,0 +1,489 @@
+package Components
+
+import (
+		"errors"
+		"fmt"
+		"io"
+		"regexp"
+		"strconv"
+		"strings"
+		"text/tabwriter"
+		"time"
+)
+
+// 签名命令处理, 参数已校验, 返回退出码
+type ConsoleHandler func(input *ConsoleInput, out io.Writer) int
+
+// 退出码
+const (
+		ExitSuccess = 0
+		ExitFailure = 1
+		ExitUsage   = 2
+)
+
+// 参数/选项 值类型
+const (
+		StringValue   = "string"
+		IntValue      = "int"
+		FloatValue    = "float"
+		BoolValue     = "bool"
+		DurationValue = "duration"
+)
+
+// 命令参数
+type ConsoleArgument struct {
+		Name        string
+		Type        string
+		Description string
+		Required    bool
+		Array       bool
+		Default     string
+}
+
+// 命令选项, Value 为 false 时 为开关
+type ConsoleOption struct {
+		Name        string
+		Shortcut    string
+		Type        string
+		Description string
+		Value       bool
+		Array       bool
+		Default     string
+}
+
+// 命令签名
+// eg: user:create {name : 用户名} {tags?*} {--admin} {--R|role=*} {--level:int=1}
+// 参数: {name} 必填, {name?} 可选, {name=tom} 默认值, {name*} 数组, {name?*} 可选数组
+// 选项: {--admin} 开关, {--role=} 取值, {--role=guest} 默认值, {--role=*} 多值, {--R|role=} 简写
+// 类型: name:int, name:float, name:bool, name:duration, 默认 string
+type CommandSignature struct {
+		Name      string
+		Arguments []*ConsoleArgument
+		Options   []*ConsoleOption
+}
+
+var (
+		signatureTokens = regexp.MustCompile(`\{\s*([^}]*?)\s*\}`)
+		signatureDesc   = regexp.MustCompile(`\s+:\s*|:\s+`)
+		signatureName   = regexp.MustCompile(`^[A-Za-z][\w\-.]*$`)
+		signatureHelp   = &ConsoleOption{Name: "help", Shortcut: "h", Type: BoolValue, Description: "display help for the command"}
+)
+
+// 解析命令签名
+func ParseSignature(signature string) (*CommandSignature, error) {
+		signature = strings.TrimSpace(signature)
+		var name = signature
+		if i := strings.IndexAny(signature, " \t{"); i >= 0 {
+				name = signature[:i]
+		}
+		if name == "" {
+				return nil, errors.New("command name is empty")
+		}
+		var sig = &CommandSignature{Name: name}
+		for _, match := range signatureTokens.FindAllStringSubmatch(signature[len(name):], -1) {
+				var (
+						token = match[1]
+						desc  string
+						err   error
+				)
+				if loc := signatureDesc.FindStringIndex(token); loc != nil {
+						token, desc = strings.TrimSpace(token[:loc[0]]), strings.TrimSpace(token[loc[1]:])
+				}
+				if strings.HasPrefix(token, "--") {
+						err = sig.addOption(token[2:], desc)
+				} else {
+						err = sig.addArgument(token, desc)
+				}
+				if err != nil {
+						return nil, fmt.Errorf("%s: %v", name, err)
+				}
+		}
+		return sig, nil
+}
+
+func (this *CommandSignature) addArgument(token string, desc string) error {
+		var arg = &ConsoleArgument{Description: desc, Required: true}
+		if i := strings.Index(token, "="); i >= 0 {
+				token, arg.Default, arg.Required = token[:i], token[i+1:], false
+		}
+		if strings.HasSuffix(token, "*") {
+				token, arg.Array = strings.TrimSuffix(token, "*"), true
+		}
+		if strings.HasSuffix(token, "?") {
+				token, arg.Required = strings.TrimSuffix(token, "?"), false
+		}
+		var err error
+		if arg.Name, arg.Type, err = this.nameOf(token); err != nil {
+				return err
+		}
+		if n := len(this.Arguments); n > 0 {
+				if last := this.Arguments[n-1]; last.Array {
+						return fmt.Errorf("argument %s after array argument %s", arg.Name, last.Name)
+				} else if arg.Required && !last.Required {
+						return fmt.Errorf("required argument %s after optional argument %s", arg.Name, last.Name)
+				}
+		}
+		if arg.Default != "" {
+				if _, err = ConvertValue(arg.Type, arg.Default); err != nil {
+						return fmt.Errorf("argument %s default: %v", arg.Name, err)
+				}
+		}
+		this.Arguments = append(this.Arguments, arg)
+		return nil
+}
+
+func (this *CommandSignature) addOption(token string, desc string) error {
+		var opt = &ConsoleOption{Description: desc, Type: BoolValue}
+		if i := strings.Index(token, "="); i >= 0 {
+				token, opt.Default, opt.Value, opt.Type = token[:i], token[i+1:], true, StringValue
+				if opt.Default == "*" {
+						opt.Default, opt.Array = "", true
+				}
+		}
+		if i := strings.Index(token, "|"); i >= 0 {
+				token, opt.Shortcut = token[i+1:], token[:i]
+				if opt.Shortcut == "" || this.option(opt.Shortcut, true) != nil {
+						return fmt.Errorf("invalid option shortcut -%s", opt.Shortcut)
+				}
+		}
+		name, typ, err := this.nameOf(token)
+		if err != nil {
+				return err
+		}
+		opt.Name = name
+		if strings.Contains(token, ":") {
+				opt.Type = typ
+				opt.Value = opt.Value || typ != BoolValue
+		}
+		if opt.Default != "" {
+				if _, err = ConvertValue(opt.Type, opt.Default); err != nil {
+						return fmt.Errorf("option --%s default: %v", opt.Name, err)
+				}
+		}
+		this.Options = append(this.Options, opt)
+		return nil
+}
+
+// 名称及类型, 名称不可重复
+func (this *CommandSignature) nameOf(token string) (string, string, error) {
+		var name, typ = token, StringValue
+		if i := strings.Index(token, ":"); i >= 0 {
+				name, typ = token[:i], token[i+1:]
+		}
+		if !signatureName.MatchString(name) {
+				return "", "", fmt.Errorf("invalid name %q", name)
+		}
+		if _, err := ConvertValue(typ, ""); err != nil && err != errEmptyValue {
+				return "", "", err
+		}
+		if this.argument(name) != nil || this.option(name, false) != nil {
+				return "", "", fmt.Errorf("duplicate name %s", name)
+		}
+		return name, typ, nil
+}
+
+func (this *CommandSignature) argument(name string) *ConsoleArgument {
+		for _, arg := range this.Arguments {
+				if arg.Name == name {
+						return arg
+				}
+		}
+		return nil
+}
+
+// 查找选项, 包括内置 --help
+func (this *CommandSignature) option(name string, shortcut bool) *ConsoleOption {
+		for _, opt := range append(this.Options, signatureHelp) {
+				if (!shortcut && opt.Name == name) || (shortcut && opt.Shortcut == name) {
+						return opt
+				}
+		}
+		return nil
+}
+
+// 解析并校验 命令参数
+func (this *CommandSignature) Parse(args []string) (*ConsoleInput, error) {
+		var (
+				input      = newConsoleInput()
+				positional []string
+		)
+		for i := 0; i < len(args); i++ {
+				var arg = args[i]
+				if arg == "--" {
+						positional = append(positional, args[i+1:]...)
+						break
+				}
+				if !strings.HasPrefix(arg, "-") || arg == "-" {
+						positional = append(positional, arg)
+						continue
+				}
+				var (
+						long     = strings.HasPrefix(arg, "--")
+						name     = strings.TrimLeft(arg, "-")
+						value    string
+						hasValue bool
+				)
+				if j := strings.Index(name, "="); j >= 0 {
+						name, value, hasValue = name[:j], name[j+1:], true
+				}
+				opt := this.option(name, !long)
+				if opt == nil {
+						return nil, fmt.Errorf("the %q option does not exist", arg)
+				}
+				if !opt.Value {
+						if !hasValue {
+								value = "true"
+						}
+						input.options[opt.Name] = []string{value}
+						continue
+				}
+				if !hasValue {
+						if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") && args[i+1] != "-" {
+								return nil, fmt.Errorf("the \"--%s\" option requires a value", opt.Name)
+						}
+						i++
+						value = args[i]
+				}
+				if opt.Array {
+						input.options[opt.Name] = append(input.options[opt.Name], value)
+				} else {
+						input.options[opt.Name] = []string{value}
+				}
+		}
+		for _, arg := range this.Arguments {
+				if len(positional) == 0 {
+						break
+				}
+				if arg.Array {
+						input.arguments[arg.Name], positional = positional, nil
+						break
+				}
+				input.arguments[arg.Name], positional = positional[:1], positional[1:]
+		}
+		if len(positional) > 0 {
+				return nil, fmt.Errorf("too many arguments: %s", strings.Join(positional, " "))
+		}
+		return input, this.validate(input)
+}
+
+// 必填校验, 默认值, 类型转换
+func (this *CommandSignature) validate(input *ConsoleInput) error {
+		var missing []string
+		for _, arg := range this.Arguments {
+				values, ok := input.arguments[arg.Name]
+				if !ok && arg.Required {
+						missing = append(missing, arg.Name)
+						continue
+				}
+				if !ok && arg.Default != "" {
+						values = []string{arg.Default}
+						input.arguments[arg.Name] = values
+				}
+				if err := input.convert(arg.Name, arg.Type, values, arg.Array); err != nil {
+						return fmt.Errorf("argument %s: %v", arg.Name, err)
+				}
+		}
+		if len(missing) > 0 {
+				return fmt.Errorf("not enough arguments (missing: %s)", strings.Join(missing, ", "))
+		}
+		for _, opt := range this.Options {
+				values, ok := input.options[opt.Name]
+				if !ok && opt.Default != "" {
+						values = []string{opt.Default}
+						input.options[opt.Name] = values
+				}
+				if err := input.convert(opt.Name, opt.Type, values, opt.Array); err != nil {
+						return fmt.Errorf("option --%s: %v", opt.Name, err)
+				}
+		}
+		return nil
+}
+
+// 用法
+func (this *CommandSignature) Usage() string {
+		var usage = []string{this.Name}
+		if len(this.Options) > 0 {
+				usage = append(usage, "[options]")
+		}
+		if len(this.Arguments) > 0 {
+				usage = append(usage, "[--]")
+		}
+		for _, arg := range this.Arguments {
+				var it = "<" + arg.Name + ">"
+				if arg.Array {
+						it += "..."
+				}
+				if !arg.Required {
+						it = "[" + it + "]"
+				}
+				usage = append(usage, it)
+		}
+		return "Usage:\n  " + strings.Join(usage, " ") + "\n"
+}
+
+// 帮助信息, 包含描述, 用法, 参数, 选项
+func (this *CommandSignature) Help(description string) string {
+		var (
+				buf    strings.Builder
+				writer = tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+		)
+		if description != "" {
+				buf.WriteString("Description:\n  " + description + "\n\n")
+		}
+		buf.WriteString(this.Usage())
+		if len(this.Arguments) > 0 {
+				fmt.Fprintln(writer, "\nArguments:")
+				for _, arg := range this.Arguments {
+						fmt.Fprintf(writer, "  %s\t%s\n", arg.Name, describe(arg.Description, arg.Type, arg.Default, arg.Array))
+				}
+		}
+		fmt.Fprintln(writer, "\nOptions:")
+		for _, opt := range append(this.Options, signatureHelp) {
+				var flag = "    --" + opt.Name
+				if opt.Shortcut != "" {
+						flag = "-" + opt.Shortcut + ", --" + opt.Name
+				}
+				if opt.Value {
+						flag += "=" + strings.ToUpper(opt.Name)
+				}
+				fmt.Fprintf(writer, "  %s\t%s\n", flag, describe(opt.Description, opt.Type, opt.Default, opt.Array))
+		}
+		_ = writer.Flush()
+		return buf.String()
+}
+
+func describe(desc string, typ string, def string, array bool) string {
+		var extras []string
+		if typ != StringValue && typ != BoolValue {
+				extras = append(extras, typ)
+		}
+		if def != "" {
+				extras = append(extras, "default: "+def)
+		}
+		if array {
+				extras = append(extras, "multiple values allowed")
+		}
+		if len(extras) == 0 {
+				return desc
+		}
+		return strings.TrimSpace(desc + " [" + strings.Join(extras, ", ") + "]")
+}
+
+var errEmptyValue = errors.New("empty value")
+
+// 按类型转换
+func ConvertValue(typ string, value string) (interface{}, error) {
+		var err error
+		switch typ {
+		case StringValue:
+				return value, nil
+		case IntValue, FloatValue, BoolValue, DurationValue:
+				if value == "" {
+						return nil, errEmptyValue
+				}
+		default:
+				return nil, fmt.Errorf("unsupported type %q", typ)
+		}
+		var v interface{}
+		switch typ {
+		case IntValue:
+				v, err = strconv.Atoi(value)
+		case FloatValue:
+				v, err = strconv.ParseFloat(value, 64)
+		case BoolValue:
+				v, err = strconv.ParseBool(value)
+		case DurationValue:
+				v, err = time.ParseDuration(value)
+		}
+		if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q", typ, value)
+		}
+		return v, nil
+}
+
+// 命令输入, 已按签名校验
+type ConsoleInput struct {
+		arguments map[string][]string
+		options   map[string][]string
+		values    map[string]interface{}
+}
+
+func newConsoleInput() *ConsoleInput {
+		var input = new(ConsoleInput)
+		input.arguments = make(map[string][]string)
+		input.options = make(map[string][]string)
+		input.values = make(map[string]interface{})
+		return input
+}
+
+func (this *ConsoleInput) convert(name string, typ string, values []string, array bool) error {
+		var converted []interface{}
+		for _, value := range values {
+				v, err := ConvertValue(typ, value)
+				if err != nil {
+						return err
+				}
+				converted = append(converted, v)
+		}
+		if array {
+				this.values[name] = converted
+		} else if len(converted) > 0 {
+				this.values[name] = converted[0]
+		}
+		return nil
+}
+
+// 参数值, 数组参数 返回首个
+func (this *ConsoleInput) Argument(name string) string {
+		if values := this.arguments[name]; len(values) > 0 {
+				return values[0]
+		}
+		return ""
+}
+
+func (this *ConsoleInput) Arguments(name string) []string {
+		return this.arguments[name]
+}
+
+// 选项值, 多值选项 返回首个
+func (this *ConsoleInput) Option(name string) string {
+		if values := this.options[name]; len(values) > 0 {
+				return values[0]
+		}
+		return ""
+}
+
+func (this *ConsoleInput) Options(name string) []string {
+		return this.options[name]
+}
+
+// 是否传入 (含默认值)
+func (this *ConsoleInput) Has(name string) bool {
+		_, ok := this.values[name]
+		return ok
+}
+
+// 类型转换后的值, 数组为 []interface{}
+func (this *ConsoleInput) Value(name string) interface{} {
+		return this.values[name]
+}
+
+func (this *ConsoleInput) Bool(name string) bool {
+		v, _ := this.values[name].(bool)
+		return v
+}
+
+func (this *ConsoleInput) Int(name string) int {
+		v, _ := this.values[name].(int)
+		return v
+}
+
+func (this *ConsoleInput) Float(name string) float64 {
+		v, _ := this.values[name].(float64)
+		return v
+}
+
+func (this *ConsoleInput) Duration(name string) time.Duration {
+		v, _ := this.values[name].(time.Duration)
+		return v
+}
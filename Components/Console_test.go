@@ -0,0 +1,74 @@
+package Components
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"testing"
+		"time"
+)
+
+func TestParseSignature(t *testing.T) {
+		Convey("Parse Signature Test", t, func() {
+				sig, err := ParseSignature("user:create {name : 用户名} {tags?*} {--admin} {--R|role=*} {--level:int=1 : 等级}")
+				So(err, ShouldBeNil)
+				So(sig.Name, ShouldEqual, "user:create")
+				So(len(sig.Arguments), ShouldEqual, 2)
+				So(*sig.Arguments[0], ShouldResemble, ConsoleArgument{Name: "name", Type: StringValue, Description: "用户名", Required: true})
+				So(sig.Arguments[1].Array, ShouldBeTrue)
+				So(sig.Arguments[1].Required, ShouldBeFalse)
+				So(len(sig.Options), ShouldEqual, 3)
+				So(*sig.Options[0], ShouldResemble, ConsoleOption{Name: "admin", Type: BoolValue})
+				So(*sig.Options[1], ShouldResemble, ConsoleOption{Name: "role", Shortcut: "R", Type: StringValue, Value: true, Array: true})
+				So(*sig.Options[2], ShouldResemble, ConsoleOption{Name: "level", Type: IntValue, Description: "等级", Value: true, Default: "1"})
+				So(sig.Usage(), ShouldContainSubstring, "user:create [options] [--] <name> [<tags>...]")
+
+				var invalid = []string{
+						"",
+						"user:create {tags*} {name}",
+						"user:create {name?} {role}",
+						"user:create {name} {name}",
+						"user:create {--level:int=x}",
+						"user:create {--h|host=}",
+						"user:create {count:uint}",
+						"user:create {1name}",
+				}
+				for _, signature := range invalid {
+						_, err = ParseSignature(signature)
+						So(err, ShouldNotBeNil)
+				}
+		})
+}
+
+func TestSignatureParse(t *testing.T) {
+		sig, _ := ParseSignature("deploy {env} {hosts?*} {--f|force} {--R|role=*} {--timeout:duration=30s} {--retry:int=}")
+		Convey("Signature Parse Test", t, func() {
+				input, err := sig.Parse([]string{"prod", "a", "-f", "-R", "web", "--role=db", "b", "--retry", "3"})
+				So(err, ShouldBeNil)
+				So(input.Argument("env"), ShouldEqual, "prod")
+				So(input.Arguments("hosts"), ShouldResemble, []string{"a", "b"})
+				So(input.Bool("force"), ShouldBeTrue)
+				So(input.Options("role"), ShouldResemble, []string{"web", "db"})
+				So(input.Duration("timeout"), ShouldEqual, 30*time.Second)
+				So(input.Int("retry"), ShouldEqual, 3)
+				So(input.Has("hosts"), ShouldBeTrue)
+
+				input, err = sig.Parse([]string{"prod", "--", "-x"})
+				So(err, ShouldBeNil)
+				So(input.Arguments("hosts"), ShouldResemble, []string{"-x"})
+				So(input.Bool("force"), ShouldBeFalse)
+				So(input.Has("retry"), ShouldBeFalse)
+
+				var failures = map[string][]string{
+						"not enough arguments (missing: env)":   {},
+						`the "--verbose" option does not exist`: {"prod", "--verbose"},
+						`the "--role" option requires a value`:  {"prod", "--role"},
+						`invalid int value "x"`:                 {"prod", "--retry=x"},
+						`invalid duration value "1"`:            {"prod", "--timeout", "1"},
+				}
+				for message, args := range failures {
+						_, err = sig.Parse(args)
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldContainSubstring, message)
+				}
+				So(sig.Help("deploy app"), ShouldContainSubstring, "-R, --role=ROLE")
+		})
+}
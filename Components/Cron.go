@@ -0,0 +1,224 @@
+package Components
+
+import (
+		"fmt"
+		"strconv"
+		"strings"
+		"time"
+)
+
+// 调度计划, 返回 from 之后的下次运行时间, 零值 表示不再运行
+type Schedule interface {
+		Next(from time.Time) time.Time
+}
+
+// cron 表达式: 分 时 日 月 周, 周日为 0 或 7
+// 支持 * , - / 月份及星期 英文缩写, 以及 @yearly @monthly @weekly @daily @hourly @every <duration>
+type CronSchedule struct {
+		expr   string
+		minute uint64
+		hour   uint64
+		dom    uint64
+		month  uint64
+		dow    uint64
+		// 日 或 周 为 * 时 另一字段生效, 否则 两者满足其一即可
+		domStar bool
+		dowStar bool
+}
+
+// 固定间隔
+type intervalSchedule time.Duration
+
+type cronBounds struct {
+		name  string
+		min   int
+		max   int
+		names map[string]int
+}
+
+var (
+		cronDescriptors = map[string]string{
+				"@yearly":   "0 0 1 1 *",
+				"@annually": "0 0 1 1 *",
+				"@monthly":  "0 0 1 * *",
+				"@weekly":   "0 0 * * 0",
+				"@daily":    "0 0 * * *",
+				"@midnight": "0 0 * * *",
+				"@hourly":   "0 * * * *",
+		}
+		cronFields = []cronBounds{
+				{name: "minute", min: 0, max: 59},
+				{name: "hour", min: 0, max: 23},
+				{name: "day of month", min: 1, max: 31},
+				{name: "month", min: 1, max: 12, names: map[string]int{
+						"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+						"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+				}},
+				{name: "day of week", min: 0, max: 7, names: map[string]int{
+						"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+				}},
+		}
+)
+
+// 解析 cron 表达式
+func ParseCron(expr string) (Schedule, error) {
+		var spec = strings.TrimSpace(expr)
+		if strings.HasPrefix(spec, "@every ") {
+				d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+				if err != nil || d < time.Second {
+						return nil, fmt.Errorf("invalid cron %q: interval must be a duration of at least 1s", expr)
+				}
+				return Every(d), nil
+		}
+		if descriptor, ok := cronDescriptors[spec]; ok {
+				spec = descriptor
+		}
+		var fields = strings.Fields(spec)
+		if len(fields) != len(cronFields) {
+				return nil, fmt.Errorf("invalid cron %q: expected %d fields, got %d", expr, len(cronFields), len(fields))
+		}
+		var (
+				schedule = &CronSchedule{expr: expr}
+				bits     = make([]uint64, len(fields))
+		)
+		for i, field := range fields {
+				value, err := cronFields[i].parse(field)
+				if err != nil {
+						return nil, fmt.Errorf("invalid cron %q: %v", expr, err)
+				}
+				bits[i] = value
+		}
+		schedule.minute, schedule.hour, schedule.dom, schedule.month, schedule.dow = bits[0], bits[1], bits[2], bits[3], bits[4]
+		// 周日 7 => 0
+		if schedule.dow&(1<<7) != 0 {
+				schedule.dow = schedule.dow&^(1<<7) | 1
+		}
+		schedule.domStar, schedule.dowStar = fields[2] == "*", fields[4] == "*"
+		return schedule, nil
+}
+
+// 固定间隔 调度, 间隔不小于 1s 时 对齐到秒
+func Every(d time.Duration) Schedule {
+		return intervalSchedule(d)
+}
+
+func (this intervalSchedule) Next(from time.Time) time.Time {
+		if time.Duration(this) < time.Second {
+				return from.Add(time.Duration(this))
+		}
+		return from.Add(time.Duration(this) - time.Duration(from.Nanosecond()))
+}
+
+func (this intervalSchedule) String() string {
+		return "@every " + time.Duration(this).String()
+}
+
+// 解析单个字段, 返回位图
+func (this cronBounds) parse(field string) (uint64, error) {
+		var bits uint64
+		for _, part := range strings.Split(field, ",") {
+				var (
+						rangeExpr = part
+						step      = 1
+						start     = this.min
+						end       = this.max
+						err       error
+				)
+				if i := strings.Index(part, "/"); i >= 0 {
+						rangeExpr = part[:i]
+						if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+								return 0, fmt.Errorf("invalid step %q in %s", part, this.name)
+						}
+				}
+				switch {
+				case rangeExpr == "*":
+				case strings.Contains(rangeExpr, "-"):
+						var bounds = strings.SplitN(rangeExpr, "-", 2)
+						if start, err = this.value(bounds[0]); err != nil {
+								return 0, err
+						}
+						if end, err = this.value(bounds[1]); err != nil {
+								return 0, err
+						}
+				default:
+						if start, err = this.value(rangeExpr); err != nil {
+								return 0, err
+						}
+						// a/n 从 a 开始 到最大值
+						if !strings.Contains(part, "/") {
+								end = start
+						}
+				}
+				if start > end {
+						return 0, fmt.Errorf("invalid range %q in %s", part, this.name)
+				}
+				for v := start; v <= end; v += step {
+						bits |= 1 << uint(v)
+				}
+		}
+		return bits, nil
+}
+
+func (this cronBounds) value(expr string) (int, error) {
+		if v, ok := this.names[strings.ToLower(expr)]; ok {
+				return v, nil
+		}
+		v, err := strconv.Atoi(expr)
+		if err != nil || v < this.min || v > this.max {
+				return 0, fmt.Errorf("%s %q out of range %d-%d", this.name, expr, this.min, this.max)
+		}
+		return v, nil
+}
+
+// 下次运行时间, 按 from 所在时区 计算, 5 年内无匹配 返回零值
+func (this *CronSchedule) Next(from time.Time) time.Time {
+		var (
+				loc   = from.Location()
+				t     = time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), from.Minute(), 0, 0, loc).Add(time.Minute)
+				limit = t.Year() + 5
+		)
+WRAP:
+		if t.Year() > limit {
+				return time.Time{}
+		}
+		for this.month&(1<<uint(t.Month())) == 0 {
+				t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+				if t.Month() == time.January {
+						goto WRAP
+				}
+		}
+		for !this.dayMatches(t) {
+				t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+				if t.Day() == 1 {
+						goto WRAP
+				}
+		}
+		for this.hour&(1<<uint(t.Hour())) == 0 {
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+				if t.Hour() == 0 {
+						goto WRAP
+				}
+		}
+		for this.minute&(1<<uint(t.Minute())) == 0 {
+				t = t.Add(time.Minute)
+				if t.Minute() == 0 {
+						goto WRAP
+				}
+		}
+		return t
+}
+
+func (this *CronSchedule) dayMatches(t time.Time) bool {
+		var (
+				dom = this.dom&(1<<uint(t.Day())) != 0
+				dow = this.dow&(1<<uint(t.Weekday())) != 0
+		)
+		if this.domStar || this.dowStar {
+				return dom && dow
+		}
+		return dom || dow
+}
+
+func (this *CronSchedule) String() string {
+		return this.expr
+}
@@ -0,0 +1,58 @@
+package Components
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"testing"
+		"time"
+)
+
+func TestParseCron(t *testing.T) {
+		Convey("Parse Cron Test", t, func() {
+				for _, expr := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "* * 0 * *", "* * * foo *", "@every 10ms", "@every x"} {
+						_, err := ParseCron(expr)
+						So(err, ShouldNotBeNil)
+				}
+				schedule, err := ParseCron("@every 5m")
+				So(err, ShouldBeNil)
+				So(schedule, ShouldEqual, Every(5*time.Minute))
+		})
+}
+
+func TestCronNext(t *testing.T) {
+		var (
+				// 周一
+				from = time.Date(2024, 1, 15, 10, 7, 30, 0, time.UTC)
+				next = func(expr string, from time.Time) time.Time {
+						schedule, err := ParseCron(expr)
+						So(err, ShouldBeNil)
+						return schedule.Next(from)
+				}
+				at = func(month time.Month, day, hour, minute int) time.Time {
+						return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+				}
+		)
+		Convey("Cron Next Test", t, func() {
+				So(next("* * * * *", from), ShouldEqual, at(1, 15, 10, 8))
+				So(next("*/15 * * * *", from), ShouldEqual, at(1, 15, 10, 15))
+				So(next("5,50 * * * *", from), ShouldEqual, at(1, 15, 10, 50))
+				So(next("@hourly", from), ShouldEqual, at(1, 15, 11, 0))
+				So(next("0 0 * * 1", from), ShouldEqual, at(1, 22, 0, 0))
+				So(next("0 0 * * 7", from), ShouldEqual, at(1, 21, 0, 0))
+				So(next("0 0 * * SUN", from), ShouldEqual, at(1, 21, 0, 0))
+				So(next("30 9 1 * *", from), ShouldEqual, at(2, 1, 9, 30))
+				So(next("0 12 * * mon-fri", at(1, 19, 13, 0)), ShouldEqual, at(1, 22, 12, 0))
+				So(next("0 0 1 jan *", from), ShouldEqual, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+				// 日 和 周 都指定时 满足其一
+				So(next("0 0 13 * 5", at(1, 1, 0, 0)), ShouldEqual, at(1, 5, 0, 0))
+				So(next("0 0 29 2 *", from), ShouldEqual, at(2, 29, 0, 0))
+				So(next("0 0 29 2 *", at(3, 1, 0, 0)), ShouldEqual, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC))
+				So(next("0 0 31 2 *", from).IsZero(), ShouldBeTrue)
+
+				shanghai, err := time.LoadLocation("Asia/Shanghai")
+				So(err, ShouldBeNil)
+				So(next("0 8 * * *", from.In(shanghai)), ShouldEqual, time.Date(2024, 1, 16, 8, 0, 0, 0, shanghai))
+
+				So(Every(90*time.Second).Next(from.Add(500*time.Millisecond)), ShouldEqual, at(1, 15, 10, 9))
+				So(Every(10*time.Millisecond).Next(from), ShouldEqual, from.Add(10*time.Millisecond))
+		})
+}
@@ -39,6 +39,7 @@ type EnvironmentProviderImpl struct {
 		bean    Contracts.SupportInterface
 		clazz   Contracts.ClazzInterface
 		app     Contracts.ApplicationContainer
+		presets map[string]bool
 		Name    string
 }
 
@@ -54,7 +55,7 @@ const (
 		EnvFileExt                       = ".env"
 		EnvironmentFileLoader            = "EnvironmentFileLoader"
 		EnvironmentProviderClass         = "EnvironmentProvider"
-		EnvironmentProviderBootPrepare   = "EnvironmentProviderBootPrepare"
+		EnvironmentProviderBootPrepare   = "EnvironmentProviderBootPrepare" // Deprecated: 使用 app.Booting
 		EnvironmentProviderRegisterAfter = "EnvironmentProviderRegisterAfter"
 )
 
@@ -90,8 +91,18 @@ var (
 )
 
 func environmentProviderNew() {
-		environment = new(EnvironmentProviderImpl)
-		environment.Name = EnvironmentProviderClass
+		environment = newEnvironmentProvider()
+}
+
+// 创建独立的环境变量服务 (非全局)
+func NewEnvironmentProvider() EnvironmentProvider {
+		return newEnvironmentProvider()
+}
+
+func newEnvironmentProvider() *EnvironmentProviderImpl {
+		var provider = new(EnvironmentProviderImpl)
+		provider.Name = EnvironmentProviderClass
+		return provider
 }
 
 func EnvironmentProviderOf() EnvironmentProvider {
@@ -129,7 +140,7 @@ func (this *EnvironmentProviderImpl) Factory(container Contracts.ApplicationCont
 }
 
 func (this *EnvironmentProviderImpl) Constructor() interface{} {
-		return EnvironmentProviderOf()
+		return NewEnvironmentProvider()
 }
 
 func (this *EnvironmentProviderImpl) Init(app Contracts.ApplicationContainer) {
@@ -162,12 +173,27 @@ func (this *EnvironmentProviderImpl) Register() {
 		// register env instance
 		this.app.Bind(this.String(), this)
 		this.app.Bind(EnvironmentAlias, this.manager)
+		this.preset()
 		this.registerAfter()
+		this.loaderBootPrepare()
+}
+
+// 应用指定的环境变量 (Contracts.AppEnvPresets), 不会被 env 文件覆盖
+func (this *EnvironmentProviderImpl) preset() {
+		values, ok := this.app.GetProfile(Contracts.AppEnvPresets).(map[string]string)
+		if !ok {
+				return
+		}
+		this.presets = make(map[string]bool)
+		for key, value := range values {
+				key = strings.ToLower(key)
+				this.manager.Storage.Store(key, value)
+				this.presets[key] = true
+		}
 }
 
 func (this *EnvironmentProviderImpl) Boot() {
 		// load env file
-		this.loaderBootPrepare()
 		this.loadEnvFile()
 		// 监听 配置服务
 }
@@ -204,22 +230,36 @@ func (this *EnvironmentProviderImpl) loadEnvFile() {
 		if b, ok := lock.(bool); ok && b {
 				return
 		}
+		if this.loadEnv() {
+				this.app.Bind(EnvironmentLock, true)
+		}
+}
+
+// 重新加载 env 文件 (忽略 EnvironmentLock)
+func (this *EnvironmentProviderImpl) Reload() {
+		this.loadEnv()
+}
+
+func (this *EnvironmentProviderImpl) loadEnv() bool {
 		loader := this.getEnvFileLoader()
 		if loader == nil {
-				return
+				return false
 		}
 		file := this.getEnvFile()
 		if file == "" {
-				return
+				return false
 		}
 		mapper := loader(file)
 		if len(mapper) == 0 {
-				return
+				return false
 		}
 		for key, v := range mapper {
+				if this.presets[strings.ToLower(key)] {
+						continue
+				}
 				this.Set(key, v)
 		}
-		this.app.Bind(EnvironmentLock, true)
+		return true
 }
 
 func (this *EnvironmentProviderImpl) getEnvFile() string {
@@ -333,7 +373,9 @@ func (this *EnvironmentProviderImpl) getEnvMapper(file string) map[string]string
 		}
 		debug := BooleanOf(loader.Mapper.GetString(Contracts.AppDebug))
 		if Debug() || debug.ValueOf() {
-				LoggerProviderOf().Debug("environment loader file : " + file)
+				if logger, ok := this.app.Get(LoggerProviderClass).(LoggerProvider); ok {
+						logger.Debug("environment loader file : " + file)
+				}
 		}
 		loader.Foreach(func(k, v interface{}) bool {
 				if key, ok := k.(string); ok {
@@ -346,40 +388,47 @@ func (this *EnvironmentProviderImpl) getEnvMapper(file string) map[string]string
 		return mapper
 }
 
+// 兼容 EnvironmentProviderBootPrepare 绑定, 转为 app.Booting 钩子
 func (this *EnvironmentProviderImpl) loaderBootPrepare() {
 		prepares := this.app.Get(EnvironmentProviderBootPrepare)
 		if prepares == nil {
 				return
 		}
 		if fn, ok := prepares.(EnvironmentBootPrepareFunc); ok {
-				fn(this.app)
+				this.app.Booting(fn)
 				return
 		}
 		if fn, ok := prepares.(func(Contracts.ApplicationContainer)); ok {
-				fn(this.app)
+				this.app.Booting(fn)
 				return
 		}
 
 		if items, ok := prepares.([]EnvironmentBootPrepareFunc); ok {
 				for _, fn := range items {
-						fn(this.app)
+						this.app.Booting(fn)
 				}
 				return
 		}
 		if items, ok := prepares.([]func(Contracts.ApplicationContainer)); ok {
 				for _, fn := range items {
-						fn(this.app)
+						this.app.Booting(fn)
 				}
 				return
 		}
 }
 
 func (this *EnvironmentProviderImpl) Set(key string, value string) {
+		if this.manager == nil {
+				this.initComponent()
+		}
 		key = strings.ToLower(key)
 		this.manager.Storage.Set(key, value)
 }
 
 func (this *EnvironmentProviderImpl) Get(key string, defaults ...string) string {
+		if this.manager == nil {
+				this.initComponent()
+		}
 		key = strings.ToLower(key)
 		v := this.manager.Storage.GetStr(key, defaults...)
 		if v == "" {
@@ -470,11 +519,16 @@ func (this *HashMapperStrKeyEntry) GetStr(key string, defaults ...string) string
 		return defaults[0]
 }
 
-// 设置节点
+// 设置节点, 字符串值 同时写入进程环境变量
 func (this *HashMapperStrKeyEntry) Set(key string, value interface{}) {
 		if v, ok := value.(string); ok {
 				_ = os.Setenv(key, v)
 		}
+		this.Store(key, value)
+}
+
+// 设置节点, 不写入进程环境变量
+func (this *HashMapperStrKeyEntry) Store(key string, value interface{}) {
 		keys := strings.SplitN(key, ".", -1)
 		index, end, exists := this.find(keys)
 		if index == -1 && len(keys) == 1 {
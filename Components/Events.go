@@ -1,16 +1,65 @@
 package Components
 
 import (
+		"fmt"
+		"github.com/prometheus/common/log"
 		"github.com/webGameLinux/kits/Contracts"
+		"path"
+		"sort"
+		"strings"
 		"sync"
 )
 
+// 事件监听器
+type EventListener func(event string, payload interface{})
+
+// 事件分发器
+type EventDispatcher interface {
+		Listen(event string, listener EventListener, priority ...int)
+		Forget(event string)
+		HasListeners(event string) bool
+		Dispatch(event string, payload interface{})
+		DispatchAsync(event string, payload interface{})
+		Close()
+}
+
 type EventBusProvider interface {
 		Contracts.Provider
+		EventDispatcher
 }
 
 type EventBusProviderImpl struct {
 		AppServiceProvider
+		listeners map[string][]*eventListener
+		mutex     sync.RWMutex
+		seq       int
+		pool      *eventWorkerPool
+		poolLock  sync.Mutex
+		// 已关闭, 不再创建工作池
+		closed bool
+}
+
+// 监听项
+type eventListener struct {
+		pattern  string
+		priority int
+		seq      int
+		handler  EventListener
+}
+
+// 异步事件任务
+type eventJob struct {
+		event    string
+		payload  interface{}
+		handlers []*eventListener
+}
+
+// 异步事件 工作池
+type eventWorkerPool struct {
+		jobs   chan *eventJob
+		wg     sync.WaitGroup
+		mutex  sync.RWMutex
+		closed bool
 }
 
 var (
@@ -19,12 +68,29 @@ var (
 )
 
 const (
+		EventBusAlias         = "events"
 		EventBusProviderClass = "EventBusProvider"
+		EventWorkersConfig    = "event.workers"
+		EventQueueConfig      = "event.queue"
+		EventWorkersDefault   = 4
+		EventQueueDefault     = 64
+		EventWildcard         = "*"
 )
 
 func newEventBus() {
-		eventBus = new(EventBusProviderImpl)
-		eventBus.Name = EventBusProviderClass
+		eventBus = newEventBusProvider()
+}
+
+// 创建独立的事件服务 (非全局)
+func NewEventBusProvider() EventBusProvider {
+		return newEventBusProvider()
+}
+
+func newEventBusProvider() *EventBusProviderImpl {
+		var provider = new(EventBusProviderImpl)
+		provider.Name = EventBusProviderClass
+		provider.listeners = make(map[string][]*eventListener)
+		return provider
 }
 
 func EventBusProviderOf() EventBusProvider {
@@ -35,7 +101,7 @@ func EventBusProviderOf() EventBusProvider {
 }
 
 func (this *EventBusProviderImpl) Constructor() interface{} {
-		return EventBusProviderOf()
+		return NewEventBusProvider()
 }
 
 func (this *EventBusProviderImpl) Factory(app Contracts.ApplicationContainer) interface{} {
@@ -43,10 +109,202 @@ func (this *EventBusProviderImpl) Factory(app Contracts.ApplicationContainer) in
 		return this
 }
 
-func (this *EventBusProviderImpl) Register() {
+func (this *EventBusProviderImpl) GetClazz() Contracts.ClazzInterface {
+		if this.Clazz == nil {
+				this.Clazz = ClazzOf(this)
+		}
+		return this.Clazz
+}
 
+func (this *EventBusProviderImpl) Register() {
+		this.app.Bind(this.String(), this)
+		this.app.Bind(EventBusAlias, this)
 }
 
 func (this *EventBusProviderImpl) Boot() {
 
 }
+
+// 监听事件
+// event    事件名, 支持通配 eg: app.*
+// priority 优先级, 越大越先执行, 默认 0
+func (this *EventBusProviderImpl) Listen(event string, listener EventListener, priority ...int) {
+		if event == "" || listener == nil {
+				return
+		}
+		if len(priority) == 0 {
+				priority = append(priority, 0)
+		}
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		this.seq++
+		this.listeners[event] = append(this.listeners[event], &eventListener{
+				pattern:  event,
+				priority: priority[0],
+				seq:      this.seq,
+				handler:  listener,
+		})
+}
+
+// 移除事件监听
+func (this *EventBusProviderImpl) Forget(event string) {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		delete(this.listeners, event)
+}
+
+func (this *EventBusProviderImpl) HasListeners(event string) bool {
+		return len(this.getListeners(event)) > 0
+}
+
+// 同步分发
+func (this *EventBusProviderImpl) Dispatch(event string, payload interface{}) {
+		for _, listener := range this.getListeners(event) {
+				this.call(listener, event, payload)
+		}
+}
+
+// 异步分发, 工作池队列满时阻塞, 工作池已关闭时 丢弃事件
+func (this *EventBusProviderImpl) DispatchAsync(event string, payload interface{}) {
+		var listeners = this.getListeners(event)
+		if len(listeners) == 0 {
+				return
+		}
+		pool := this.getPool()
+		if pool == nil || !pool.send(&eventJob{event: event, payload: payload, handlers: listeners}) {
+				this.logger(fmt.Sprintf("event %s dropped, event bus closed", event))
+		}
+}
+
+// 关闭工作池, 等待异步事件处理完成, 之后的异步事件 被丢弃
+func (this *EventBusProviderImpl) Close() {
+		this.poolLock.Lock()
+		defer this.poolLock.Unlock()
+		this.closed = true
+		if this.pool == nil {
+				return
+		}
+		this.pool.close()
+		this.pool = nil
+}
+
+func (this *EventBusProviderImpl) Destroy() {
+		this.Close()
+}
+
+// 获取匹配的监听器 按优先级排序
+func (this *EventBusProviderImpl) getListeners(event string) []*eventListener {
+		var listeners []*eventListener
+		this.mutex.RLock()
+		for pattern, items := range this.listeners {
+				if EventMatch(pattern, event) {
+						listeners = append(listeners, items...)
+				}
+		}
+		this.mutex.RUnlock()
+		sort.SliceStable(listeners, func(i, j int) bool {
+				if listeners[i].priority == listeners[j].priority {
+						return listeners[i].seq < listeners[j].seq
+				}
+				return listeners[i].priority > listeners[j].priority
+		})
+		return listeners
+}
+
+// 执行监听, 恢复 panic 并记录日志
+func (this *EventBusProviderImpl) call(listener *eventListener, event string, payload interface{}) {
+		defer func() {
+				if err := recover(); err != nil {
+						this.logger(fmt.Sprintf("event %s listener %s panic: %v", event, listener.pattern, err))
+				}
+		}()
+		listener.handler(event, payload)
+}
+
+func (this *EventBusProviderImpl) logger(message string) {
+		if this.app != nil {
+				if logger, ok := this.app.Get(LoggerAlias).(Logger); ok {
+						logger.Error(message)
+						return
+				}
+		}
+		log.Error(message)
+}
+
+// 懒加载工作池, 已关闭时 返回 nil
+func (this *EventBusProviderImpl) getPool() *eventWorkerPool {
+		this.poolLock.Lock()
+		defer this.poolLock.Unlock()
+		if this.closed {
+				return nil
+		}
+		if this.pool == nil {
+				workers, queue := EventWorkersDefault, EventQueueDefault
+				if this.app != nil {
+						if config, ok := this.app.Get(ConfigureProviderClass).(ConfigureProvider); ok {
+								workers = config.Int(EventWorkersConfig, workers)
+								queue = config.Int(EventQueueConfig, queue)
+						}
+				}
+				this.pool = newEventWorkerPool(this, workers, queue)
+		}
+		return this.pool
+}
+
+func newEventWorkerPool(bus *EventBusProviderImpl, workers int, queue int) *eventWorkerPool {
+		var pool = new(eventWorkerPool)
+		if workers <= 0 {
+				workers = EventWorkersDefault
+		}
+		if queue < 0 {
+				queue = EventQueueDefault
+		}
+		pool.jobs = make(chan *eventJob, queue)
+		for i := 0; i < workers; i++ {
+				pool.wg.Add(1)
+				go func() {
+						defer pool.wg.Done()
+						for job := range pool.jobs {
+								for _, listener := range job.handlers {
+										bus.call(listener, job.event, job.payload)
+								}
+						}
+				}()
+		}
+		return pool
+}
+
+// 投递任务, 已关闭时 返回 false
+func (this *eventWorkerPool) send(job *eventJob) bool {
+		this.mutex.RLock()
+		defer this.mutex.RUnlock()
+		if this.closed {
+				return false
+		}
+		this.jobs <- job
+		return true
+}
+
+// 关闭任务队列, 等待处理完成
+func (this *eventWorkerPool) close() {
+		this.mutex.Lock()
+		if !this.closed {
+				this.closed = true
+				close(this.jobs)
+		}
+		this.mutex.Unlock()
+		this.wg.Wait()
+}
+
+// 事件名匹配
+// pattern 以 . 分段, * 匹配单段 eg: app.* 匹配 app.started, 单独 * 匹配所有事件
+func EventMatch(pattern string, event string) bool {
+		if pattern == event || pattern == EventWildcard {
+				return true
+		}
+		if !strings.Contains(pattern, EventWildcard) {
+				return false
+		}
+		ok, err := path.Match(strings.Replace(pattern, ".", "/", -1), strings.Replace(event, ".", "/", -1))
+		return err == nil && ok
+}
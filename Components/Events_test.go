@@ -0,0 +1,109 @@
+package Components
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"strings"
+		"sync"
+		"sync/atomic"
+		"testing"
+		"time"
+)
+
+func newTestEventBus() *EventBusProviderImpl {
+		var bus = new(EventBusProviderImpl)
+		bus.Name = EventBusProviderClass
+		bus.listeners = make(map[string][]*eventListener)
+		return bus
+}
+
+func TestEventMatch(t *testing.T) {
+		Convey("Event Match Test", t, func() {
+				So(EventMatch("app.started", "app.started"), ShouldBeTrue)
+				So(EventMatch("app.*", "app.started"), ShouldBeTrue)
+				So(EventMatch("app.*", "app.config.reloaded"), ShouldBeFalse)
+				So(EventMatch("*.reloaded", "config.reloaded"), ShouldBeTrue)
+				So(EventMatch("*", "config.reloaded"), ShouldBeTrue)
+				So(EventMatch("app.stopped", "app.started"), ShouldBeFalse)
+		})
+}
+
+func TestEventBusDispatch(t *testing.T) {
+		var (
+				bus   = newTestEventBus()
+				calls []string
+		)
+		bus.Listen("app.started", func(event string, payload interface{}) {
+				calls = append(calls, "low")
+		}, -1)
+		bus.Listen("app.*", func(event string, payload interface{}) {
+				calls = append(calls, "wildcard")
+		})
+		bus.Listen("app.started", func(event string, payload interface{}) {
+				calls = append(calls, "high")
+		}, 10)
+		bus.Listen("app.started", func(event string, payload interface{}) {
+				panic("listener failed")
+		}, 5)
+		Convey("Event Bus Dispatch Test", t, func() {
+				So(bus.HasListeners("app.started"), ShouldBeTrue)
+				So(bus.HasListeners("config.reloaded"), ShouldBeFalse)
+				bus.Dispatch("app.started", nil)
+				So(strings.Join(calls, ","), ShouldEqual, "high,wildcard,low")
+				bus.Forget("app.*")
+				So(bus.HasListeners("app.stopped"), ShouldBeFalse)
+		})
+}
+
+func TestEventBusDispatchAsync(t *testing.T) {
+		var (
+				bus   = newTestEventBus()
+				mutex sync.Mutex
+				sum   int
+		)
+		bus.Listen("job.*", func(event string, payload interface{}) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				sum += payload.(int)
+		})
+		Convey("Event Bus Async Test", t, func() {
+				for i := 1; i <= 100; i++ {
+						bus.DispatchAsync("job.done", i)
+				}
+				bus.Close()
+				So(sum, ShouldEqual, 5050)
+		})
+}
+
+func TestEventBusDispatchAfterClose(t *testing.T) {
+		var (
+				bus   = newTestEventBus()
+				wg    sync.WaitGroup
+				calls int32
+		)
+		bus.Listen("job.*", func(event string, payload interface{}) {})
+		bus.Listen("late.*", func(event string, payload interface{}) {
+				atomic.AddInt32(&calls, 1)
+		})
+		Convey("Event Bus Dispatch After Close Test", t, func() {
+				pool := bus.getPool()
+				for i := 0; i < 8; i++ {
+						wg.Add(1)
+						go func() {
+								defer wg.Done()
+								for j := 0; j < 100; j++ {
+										bus.DispatchAsync("job.done", j)
+								}
+						}()
+				}
+				bus.Close()
+				wg.Wait()
+				bus.Close()
+				So(pool.send(&eventJob{event: "job.done"}), ShouldBeFalse)
+
+				// 关闭后 不再创建工作池, 事件被丢弃
+				bus.DispatchAsync("late.done", 1)
+				time.Sleep(50 * time.Millisecond)
+				So(atomic.LoadInt32(&calls), ShouldEqual, 0)
+				So(bus.getPool(), ShouldBeNil)
+		})
+}
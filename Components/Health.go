@@ -0,0 +1,329 @@
+package Components
+
+import (
+		"context"
+		"encoding/json"
+		"errors"
+		"fmt"
+		"github.com/webGameLinux/kits/Contracts"
+		"net/http"
+		"sort"
+		"sync"
+		"time"
+)
+
+// 健康检查, 返回详情; error 不为空 表示不健康
+type HealthChecker func(ctx context.Context) (map[string]interface{}, error)
+
+// 健康检查注册中心
+type HealthRegistry interface {
+		Add(name string, checker HealthChecker)
+		Remove(name string)
+		Names() []string
+		Run() HealthReport
+		Report() HealthReport
+		Ready() bool
+		LivenessHandler() http.HandlerFunc
+		ReadinessHandler() http.HandlerFunc
+}
+
+// 可通过标签 HealthCheckTag 发现的健康检查服务
+type HealthCheckInterface interface {
+		HealthName() string
+		HealthCheck(ctx context.Context) (map[string]interface{}, error)
+}
+
+type HealthRegistryProvider interface {
+		Contracts.Provider
+		HealthRegistry
+}
+
+// 单项检查结果
+type HealthResult struct {
+		Name      string                 `json:"name"`
+		Status    string                 `json:"status"`
+		Latency   time.Duration          `json:"-"`
+		Details   map[string]interface{} `json:"details,omitempty"`
+		Error     string                 `json:"error,omitempty"`
+		CheckedAt time.Time              `json:"checked_at"`
+}
+
+// 汇总结果
+type HealthReport struct {
+		Status string         `json:"status"`
+		Checks []HealthResult `json:"checks"`
+}
+
+type HealthRegistryProviderImpl struct {
+		AppServiceProvider
+		checkers map[string]HealthChecker
+		results  map[string]HealthResult
+		mutex    sync.RWMutex
+}
+
+var (
+		healthInstanceLock sync.Once
+		healthRegistry     *HealthRegistryProviderImpl
+)
+
+const (
+		HealthUp                    = "up"
+		HealthDown                  = "down"
+		HealthRegistryAlias         = "health"
+		HealthRegistryProviderClass = "HealthRegistryProvider"
+		HealthCheckTag              = "health.check"
+		HealthTimeoutConfig         = "health.timeout"
+		HealthTimeoutDefault        = 3 * time.Second
+		HealthLivenessPath          = "/healthz"
+		HealthReadinessPath         = "/readyz"
+)
+
+func newHealthRegistry() {
+		healthRegistry = newHealthRegistryProvider()
+}
+
+// 创建独立的健康检查服务 (非全局)
+func NewHealthRegistryProvider() HealthRegistryProvider {
+		return newHealthRegistryProvider()
+}
+
+func newHealthRegistryProvider() *HealthRegistryProviderImpl {
+		var provider = new(HealthRegistryProviderImpl)
+		provider.Name = HealthRegistryProviderClass
+		provider.checkers = make(map[string]HealthChecker)
+		provider.results = make(map[string]HealthResult)
+		return provider
+}
+
+func HealthRegistryProviderOf() HealthRegistryProvider {
+		if healthRegistry == nil {
+				healthInstanceLock.Do(newHealthRegistry)
+		}
+		return healthRegistry
+}
+
+func (this *HealthRegistryProviderImpl) Constructor() interface{} {
+		return NewHealthRegistryProvider()
+}
+
+func (this *HealthRegistryProviderImpl) Factory(app Contracts.ApplicationContainer) interface{} {
+		this.Init(app)
+		return this
+}
+
+func (this *HealthRegistryProviderImpl) GetClazz() Contracts.ClazzInterface {
+		if this.Clazz == nil {
+				this.Clazz = ClazzOf(this)
+		}
+		return this.Clazz
+}
+
+func (this *HealthRegistryProviderImpl) Register() {
+		this.app.Bind(this.String(), this)
+		this.app.Bind(HealthRegistryAlias, this)
+}
+
+func (this *HealthRegistryProviderImpl) Boot() {
+
+}
+
+// 添加检查项, 同名覆盖
+func (this *HealthRegistryProviderImpl) Add(name string, checker HealthChecker) {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		this.checkers[name] = checker
+		delete(this.results, name)
+}
+
+// 移除检查项
+func (this *HealthRegistryProviderImpl) Remove(name string) {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		delete(this.checkers, name)
+		delete(this.results, name)
+}
+
+// 检查项名 (有序)
+func (this *HealthRegistryProviderImpl) Names() []string {
+		return healthNames(this.all())
+}
+
+// 并发执行所有检查, 缓存并返回结果
+func (this *HealthRegistryProviderImpl) Run() HealthReport {
+		var (
+				checkers = this.all()
+				names    = healthNames(checkers)
+				results  = make([]HealthResult, len(names))
+				wg       sync.WaitGroup
+				timeout  = this.timeout()
+		)
+		for i, name := range names {
+				checker := checkers[name]
+				if checker == nil {
+						continue
+				}
+				wg.Add(1)
+				go func(i int, name string, checker HealthChecker) {
+						defer wg.Done()
+						results[i] = this.check(name, checker, timeout)
+				}(i, name, checker)
+		}
+		wg.Wait()
+		this.mutex.Lock()
+		this.results = make(map[string]HealthResult)
+		for _, result := range results {
+				this.results[result.Name] = result
+		}
+		this.mutex.Unlock()
+		return newHealthReport(results)
+}
+
+// 缓存结果, 存在未执行的检查项时 重新执行
+func (this *HealthRegistryProviderImpl) Report() HealthReport {
+		var results []HealthResult
+		checkers := this.all()
+		this.mutex.RLock()
+		for name := range checkers {
+				result, ok := this.results[name]
+				if !ok {
+						this.mutex.RUnlock()
+						return this.Run()
+				}
+				results = append(results, result)
+		}
+		this.mutex.RUnlock()
+		sort.Slice(results, func(i, j int) bool {
+				return results[i].Name < results[j].Name
+		})
+		return newHealthReport(results)
+}
+
+// 添加的检查项 和 标签 HealthCheckTag 下的检查服务, 同名时 添加的优先
+func (this *HealthRegistryProviderImpl) all() map[string]HealthChecker {
+		var checkers = make(map[string]HealthChecker)
+		if this.app != nil {
+				for _, it := range this.app.Tagged(HealthCheckTag) {
+						if check, ok := it.(HealthCheckInterface); ok {
+								checkers[check.HealthName()] = check.HealthCheck
+						}
+				}
+		}
+		this.mutex.RLock()
+		defer this.mutex.RUnlock()
+		for name, checker := range this.checkers {
+				checkers[name] = checker
+		}
+		return checkers
+}
+
+// 检查项名 排序
+func healthNames(checkers map[string]HealthChecker) []string {
+		var names = make([]string, 0, len(checkers))
+		for name := range checkers {
+				names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+}
+
+// 是否就绪
+func (this *HealthRegistryProviderImpl) Ready() bool {
+		return this.Report().Status == HealthUp
+}
+
+// 存活检查, 进程可响应即存活
+func (this *HealthRegistryProviderImpl) LivenessHandler() http.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) {
+				writeHealth(writer, http.StatusOK, map[string]string{"status": HealthUp})
+		}
+}
+
+// 就绪检查, 返回各检查项详情, 不健康时 503
+func (this *HealthRegistryProviderImpl) ReadinessHandler() http.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) {
+				var (
+						report = this.Report()
+						code   = http.StatusOK
+				)
+				if report.Status != HealthUp {
+						code = http.StatusServiceUnavailable
+				}
+				writeHealth(writer, code, report)
+		}
+}
+
+// 执行单项检查, 超时或 panic 视为不健康
+func (this *HealthRegistryProviderImpl) check(name string, checker HealthChecker, timeout time.Duration) HealthResult {
+		type checked struct {
+				details map[string]interface{}
+				err     error
+		}
+		var (
+				result = HealthResult{Name: name, CheckedAt: time.Now()}
+				done   = make(chan checked, 1)
+				err    error
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		go func() {
+				defer func() {
+						if err := recover(); err != nil {
+								done <- checked{err: fmt.Errorf("panic: %v", err)}
+						}
+				}()
+				details, err := checker(ctx)
+				done <- checked{details: details, err: err}
+		}()
+		select {
+		case res := <-done:
+				result.Details, err = res.details, res.err
+		case <-ctx.Done():
+				err = errors.New("timeout after " + timeout.String())
+		}
+		result.Latency = time.Since(result.CheckedAt)
+		result.Status = HealthUp
+		if err != nil {
+				result.Status = HealthDown
+				result.Error = err.Error()
+		}
+		return result
+}
+
+func (this *HealthRegistryProviderImpl) timeout() time.Duration {
+		if this.app != nil {
+				if config, ok := this.app.Get(ConfigureProviderClass).(ConfigureProvider); ok {
+						if d, err := time.ParseDuration(config.Get(HealthTimeoutConfig)); err == nil && d > 0 {
+								return d
+						}
+				}
+		}
+		return HealthTimeoutDefault
+}
+
+// json 输出 耗时(毫秒)
+func (this HealthResult) MarshalJSON() ([]byte, error) {
+		type result HealthResult
+		return json.Marshal(struct {
+				result
+				Latency float64 `json:"latency_ms"`
+		}{result(this), float64(this.Latency) / float64(time.Millisecond)})
+}
+
+func newHealthReport(results []HealthResult) HealthReport {
+		var report = HealthReport{Status: HealthUp, Checks: results}
+		if report.Checks == nil {
+				report.Checks = []HealthResult{}
+		}
+		for _, result := range results {
+				if result.Status != HealthUp {
+						report.Status = HealthDown
+				}
+		}
+		return report
+}
+
+func writeHealth(writer http.ResponseWriter, code int, body interface{}) {
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		writer.WriteHeader(code)
+		_ = json.NewEncoder(writer).Encode(body)
+}
@@ -0,0 +1,135 @@
+package Components
+
+import (
+		"context"
+		"encoding/json"
+		"errors"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"net/http"
+		"net/http/httptest"
+		"testing"
+		"time"
+)
+
+type taggedHealthApp struct {
+		Contracts.ApplicationContainer
+		checks []interface{}
+}
+
+type taggedHealthCheck struct {
+		name string
+		err  error
+}
+
+func (this *taggedHealthApp) Get(id string) interface{} {
+		return nil
+}
+
+func (this *taggedHealthApp) Tagged(tag string) []interface{} {
+		if tag == HealthCheckTag {
+				return this.checks
+		}
+		return nil
+}
+
+func (this *taggedHealthCheck) HealthName() string {
+		return this.name
+}
+
+func (this *taggedHealthCheck) HealthCheck(ctx context.Context) (map[string]interface{}, error) {
+		return nil, this.err
+}
+
+func newTestHealthRegistry() *HealthRegistryProviderImpl {
+		var registry = new(HealthRegistryProviderImpl)
+		registry.Name = HealthRegistryProviderClass
+		registry.checkers = make(map[string]HealthChecker)
+		registry.results = make(map[string]HealthResult)
+		return registry
+}
+
+func TestHealthRegistryRun(t *testing.T) {
+		var (
+				registry = newTestHealthRegistry()
+				calls    int
+		)
+		registry.Add("redis", func(ctx context.Context) (map[string]interface{}, error) {
+				calls++
+				return map[string]interface{}{"addr": "127.0.0.1:6379"}, nil
+		})
+		Convey("Health Registry Run Test", t, func() {
+				report := registry.Run()
+				So(report.Status, ShouldEqual, HealthUp)
+				So(len(report.Checks), ShouldEqual, 1)
+				So(report.Checks[0].Details["addr"], ShouldEqual, "127.0.0.1:6379")
+				So(registry.Ready(), ShouldBeTrue)
+				So(calls, ShouldEqual, 1)
+				registry.Add("mysql", func(ctx context.Context) (map[string]interface{}, error) {
+						return nil, errors.New("connection refused")
+				})
+				report = registry.Report()
+				So(report.Status, ShouldEqual, HealthDown)
+				So(registry.Names(), ShouldResemble, []string{"mysql", "redis"})
+				So(report.Checks[0].Error, ShouldEqual, "connection refused")
+				So(calls, ShouldEqual, 2)
+				registry.Remove("mysql")
+				So(registry.Ready(), ShouldBeTrue)
+				So(calls, ShouldEqual, 2)
+		})
+}
+
+func TestHealthRegistryFailures(t *testing.T) {
+		var registry = newTestHealthRegistry()
+		registry.Add("slow", func(ctx context.Context) (map[string]interface{}, error) {
+				time.Sleep(HealthTimeoutDefault + time.Second)
+				return nil, nil
+		})
+		registry.Add("panic", func(ctx context.Context) (map[string]interface{}, error) {
+				panic("broken")
+		})
+		Convey("Health Registry Failures Test", t, func() {
+				report := registry.Run()
+				So(report.Status, ShouldEqual, HealthDown)
+				So(report.Checks[0].Error, ShouldEqual, "panic: broken")
+				So(report.Checks[1].Error, ShouldContainSubstring, "timeout")
+				So(report.Checks[1].Latency, ShouldBeLessThan, HealthTimeoutDefault+time.Second)
+		})
+}
+
+func TestHealthRegistryHandler(t *testing.T) {
+		var registry = newTestHealthRegistry()
+		registry.Add("nats", func(ctx context.Context) (map[string]interface{}, error) {
+				return nil, errors.New("nats connect failed")
+		})
+		Convey("Health Registry Handler Test", t, func() {
+				recorder := httptest.NewRecorder()
+				registry.LivenessHandler()(recorder, httptest.NewRequest(http.MethodGet, HealthLivenessPath, nil))
+				So(recorder.Code, ShouldEqual, http.StatusOK)
+				recorder = httptest.NewRecorder()
+				registry.ReadinessHandler()(recorder, httptest.NewRequest(http.MethodGet, HealthReadinessPath, nil))
+				So(recorder.Code, ShouldEqual, http.StatusServiceUnavailable)
+				var body map[string]interface{}
+				So(json.Unmarshal(recorder.Body.Bytes(), &body), ShouldBeNil)
+				So(body["status"], ShouldEqual, HealthDown)
+				check := body["checks"].([]interface{})[0].(map[string]interface{})
+				So(check["name"], ShouldEqual, "nats")
+				So(check, ShouldContainKey, "latency_ms")
+		})
+}
+
+func TestHealthRegistryTagged(t *testing.T) {
+		var registry = newTestHealthRegistry()
+		registry.app = &taggedHealthApp{checks: []interface{}{
+				&taggedHealthCheck{name: "etcd", err: errors.New("etcd connect failed")},
+				&taggedHealthCheck{name: "nats"},
+				"not a check",
+		}}
+		registry.Add("etcd", func(ctx context.Context) (map[string]interface{}, error) {
+				return nil, nil
+		})
+		Convey("Health Registry Tagged Test", t, func() {
+				So(registry.Names(), ShouldResemble, []string{"etcd", "nats"})
+				So(registry.Ready(), ShouldBeTrue)
+		})
+}
@@ -3,9 +3,12 @@ package Components
 import (
 		"encoding/json"
 		"errors"
+		"fmt"
 		"github.com/fatih/structs"
 		"github.com/mitchellh/mapstructure"
+		"github.com/webGameLinux/kits/Libs/Errors"
 		"reflect"
+		"strings"
 )
 
 type Injector struct {
@@ -15,6 +18,10 @@ type Injector struct {
 		DefaultTag string
 }
 
+const (
+		InjectTag = "inject"
+)
+
 var (
 		DefaultInjectorTags = []string{
 				"json", "inject", "mapstructure", "toml","xml",
@@ -176,3 +183,108 @@ func (this *Injector) checkDist(dist interface{}) bool {
 func (this *Injector) Error() error {
 		return this.err
 }
+
+
+// 依赖解析器
+// id 为空表示按类型解析
+type InjectResolver func(id string, typ reflect.Type) (interface{}, bool)
+
+// 依赖注入
+// dist  <struct> ptr, 扫描 inject tag 字段
+// resolver 容器解析器
+// 返回无法解析字段列表的错误
+func (this *Injector) Autowire(dist interface{}, resolver InjectResolver) error {
+		if !this.checkDist(dist) {
+				return this.err
+		}
+		var (
+				missing []string
+				value   = reflect.ValueOf(dist)
+		)
+		this.err = nil
+		this.autowire(value, resolver, "", make(map[uintptr]bool), &missing)
+		if len(missing) > 0 {
+				this.err = Errors.UnresolvableError(value.Type().String() + " fields [" + strings.Join(missing, ", ") + "]")
+		}
+		return this.err
+}
+
+// 递归注入,已赋值字段只做递归
+func (this *Injector) autowire(value reflect.Value, resolver InjectResolver, prefix string, visited map[uintptr]bool, missing *[]string) {
+		if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+				return
+		}
+		if visited[value.Pointer()] {
+				return
+		}
+		visited[value.Pointer()] = true
+		var (
+				elem = value.Elem()
+				typ  = elem.Type()
+		)
+		for i := 0; i < typ.NumField(); i++ {
+				field := typ.Field(i)
+				id, ok := field.Tag.Lookup(InjectTag)
+				if !ok {
+						continue
+				}
+				var (
+						name = prefix + field.Name
+						fv   = elem.Field(i)
+				)
+				if !fv.CanSet() {
+						*missing = append(*missing, name+"<unexported>")
+						continue
+				}
+				if !fv.IsZero() {
+						this.autowire(fv, resolver, name+".", visited, missing)
+						continue
+				}
+				obj, ok := resolver(id, field.Type)
+				if !ok {
+						*missing = append(*missing, name+"<"+this.Describe(id, field.Type)+">")
+						continue
+				}
+				if !this.Assign(fv, obj) {
+						*missing = append(*missing, fmt.Sprintf("%s<%s, got %T>", name, this.Describe(id, field.Type), obj))
+						continue
+				}
+				this.autowire(fv, resolver, name+".", visited, missing)
+		}
+}
+
+// 依赖描述
+func (this *Injector) Describe(id string, typ reflect.Type) string {
+		if id == "" {
+				return "by type " + typ.String()
+		}
+		return id
+}
+
+// 是否可注入到对应类型
+func (this *Injector) Assignable(obj interface{}, typ reflect.Type) bool {
+		if obj == nil {
+				return false
+		}
+		t := reflect.TypeOf(obj)
+		if t.AssignableTo(typ) {
+				return true
+		}
+		return t.Kind() == reflect.Ptr && t.Elem().AssignableTo(typ)
+}
+
+// 赋值
+func (this *Injector) Assign(dist reflect.Value, obj interface{}) bool {
+		if !dist.CanSet() || !this.Assignable(obj, dist.Type()) {
+				return false
+		}
+		v := reflect.ValueOf(obj)
+		if !v.Type().AssignableTo(dist.Type()) {
+				if v.IsNil() {
+						return false
+				}
+				v = v.Elem()
+		}
+		dist.Set(v)
+		return true
+}
@@ -45,8 +45,18 @@ func LoggerProviderOf() LoggerProvider {
 }
 
 func loggerProviderNew() {
-		loggerInstance = new(LoggerProviderImpl)
-		loggerInstance.init()
+		loggerInstance = newLoggerProvider()
+}
+
+// 创建独立的日志服务 (非全局)
+func NewLoggerProvider() LoggerProvider {
+		return newLoggerProvider()
+}
+
+func newLoggerProvider() *LoggerProviderImpl {
+		var provider = new(LoggerProviderImpl)
+		provider.init()
+		return provider
 }
 
 func (this *LoggerProviderImpl) GetClazz() Contracts.ClazzInterface {
@@ -90,11 +100,31 @@ func (this *LoggerProviderImpl) GetSupportBean() Contracts.SupportInterface {
 func (this *LoggerProviderImpl) Register() {
 		this.app.Bind(this.String(), this)
 		this.app.Singleton(LoggerAlias, this.getLoggerInstance)
+		this.app.Scoped(RequestLoggerAlias, this.getRequestLogger)
+}
+
+// 请求日志, 每条日志 带请求关联id
+func (this *LoggerProviderImpl) getRequestLogger(scope Contracts.ApplicationContainer) interface{} {
+		base := this.logger()
+		if base == nil {
+				return nil
+		}
+		logger := &requestLogger{Logger: base}
+		if id, ok := scope.Get(RequestIdAlias).(string); ok {
+				logger.id = id
+		}
+		return logger
 }
 
 func (this *LoggerProviderImpl) getLoggerInstance(app Contracts.ApplicationContainer) interface{} {
 		this.Init(app)
 		this.initLoggerBird()
+		// 命令行 -v 指定的日志级别
+		if count, ok := app.GetProfile(Contracts.AppVerbose).(int); ok && this.instance != nil {
+				if level := VerboseLevel(count); level != "" {
+						this.instance.SetLevel(level)
+				}
+		}
 		return this.instance
 }
 
@@ -127,17 +157,29 @@ func (this *LoggerProviderImpl) Warn(args ...interface{}) {
 		this.logger().Warn(args...)
 }
 
+// 日志依赖配置
+func (this *LoggerProviderImpl) DependsOn() []string {
+		return []string{ConfigureProviderClass}
+}
+
 func (this *LoggerProviderImpl) Factory(app Contracts.ApplicationContainer) interface{} {
-		logger := this.Constructor()
-		if loggerProvider, ok := logger.(*LoggerProviderImpl); ok {
-				loggerProvider.Init(app)
-				return loggerProvider.instance
-		}
+		this.Init(app)
 		return this.instance
 }
 
 func (this *LoggerProviderImpl) Constructor() interface{} {
-		return LoggerProviderOf()
+		return NewLoggerProvider()
+}
+
+// 输出详细程度 对应的日志级别, -v: debug, -vv: trace, 未指定 返回空
+func VerboseLevel(count int) string {
+		switch {
+		case count <= 0:
+				return ""
+		case count == 1:
+				return "debug"
+		}
+		return "trace"
 }
 
 func (this *LoggerProviderImpl) init() {
@@ -150,3 +192,32 @@ func (this *LoggerProviderImpl) logger() Logger {
 		}
 		return this.instance
 }
+
+// 带关联id 的日志
+type requestLogger struct {
+		Logger
+		id string
+}
+
+func (this *requestLogger) Error(args ...interface{}) {
+		this.Logger.Error(this.with(args)...)
+}
+
+func (this *requestLogger) Debug(args ...interface{}) {
+		this.Logger.Debug(this.with(args)...)
+}
+
+func (this *requestLogger) Info(args ...interface{}) {
+		this.Logger.Info(this.with(args)...)
+}
+
+func (this *requestLogger) Warn(args ...interface{}) {
+		this.Logger.Warn(this.with(args)...)
+}
+
+func (this *requestLogger) with(args []interface{}) []interface{} {
+		if this.id == "" {
+				return args
+		}
+		return append([]interface{}{"[" + this.id + "]"}, args...)
+}
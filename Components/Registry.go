@@ -0,0 +1,66 @@
+package Components
+
+import (
+		"github.com/webGameLinux/kits/Contracts"
+		"sort"
+		"strings"
+		"sync"
+)
+
+// 服务提供器工厂, 每次调用 返回新的实例
+type ProviderFactory func() Contracts.Provider
+
+var (
+		providerRegistry      = make(map[string]ProviderFactory)
+		providerRegistryMutex sync.RWMutex
+)
+
+// 注册服务提供器工厂, 供配置清单 app.providers 按名称加载
+// 通常在包 init() 中调用, eg: Components.RegisterProvider("RedisProvider", NewRedisProvider)
+func RegisterProvider(name string, factory ProviderFactory) {
+		if name == "" || factory == nil {
+				return
+		}
+		providerRegistryMutex.Lock()
+		defer providerRegistryMutex.Unlock()
+		providerRegistry[name] = factory
+}
+
+// 按名称获取服务提供器工厂, 名称不区分大小写
+func ProviderFactoryOf(name string) ProviderFactory {
+		providerRegistryMutex.RLock()
+		defer providerRegistryMutex.RUnlock()
+		if factory, ok := providerRegistry[name]; ok {
+				return factory
+		}
+		for key, factory := range providerRegistry {
+				if strings.EqualFold(key, name) {
+						return factory
+				}
+		}
+		return nil
+}
+
+// 已注册的服务提供器名称
+func RegisteredProviders() []string {
+		providerRegistryMutex.RLock()
+		var names = make([]string, 0, len(providerRegistry))
+		for name := range providerRegistry {
+				names = append(names, name)
+		}
+		providerRegistryMutex.RUnlock()
+		sort.Strings(names)
+		return names
+}
+
+func init() {
+		RegisterProvider(AppBootstrapperClass, func() Contracts.Provider { return NewAppBootstrapper() })
+		RegisterProvider(CommandLineProviderClass, func() Contracts.Provider { return NewCommandLineArgsProvider() })
+		RegisterProvider(EnvironmentProviderClass, func() Contracts.Provider { return NewEnvironmentProvider() })
+		RegisterProvider(ConfigureProviderClass, func() Contracts.Provider { return NewConfigureProvider() })
+		RegisterProvider(LoggerProviderClass, func() Contracts.Provider { return NewLoggerProvider() })
+		RegisterProvider(EventBusProviderClass, func() Contracts.Provider { return NewEventBusProvider() })
+		RegisterProvider(HealthRegistryProviderClass, func() Contracts.Provider { return NewHealthRegistryProvider() })
+		RegisterProvider(SchemaServiceProviderClass, func() Contracts.Provider { return SchemaServiceProviderOf() })
+		RegisterProvider(ScheduleProviderClass, func() Contracts.Provider { return NewScheduleProvider() })
+}
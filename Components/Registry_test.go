@@ -0,0 +1,23 @@
+package Components
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"testing"
+)
+
+func TestProviderRegistry(t *testing.T) {
+		RegisterProvider("RegistryTestProvider", func() Contracts.Provider { return ServiceProviderOf() })
+		RegisterProvider("", func() Contracts.Provider { return ServiceProviderOf() })
+		RegisterProvider("RegistryNilProvider", nil)
+		Convey("Provider Registry Test", t, func() {
+				So(ProviderFactoryOf("RegistryTestProvider"), ShouldNotBeNil)
+				So(ProviderFactoryOf("registrytestprovider"), ShouldNotBeNil)
+				So(ProviderFactoryOf("RegistryNilProvider"), ShouldBeNil)
+				So(ProviderFactoryOf("RegistryMissing"), ShouldBeNil)
+				So(RegisteredProviders(), ShouldContain, EnvironmentProviderClass)
+				So(RegisteredProviders(), ShouldContain, "RegistryTestProvider")
+				So(RegisteredProviders(), ShouldNotContain, "")
+				So(ProviderFactoryOf(LoggerProviderClass)(), ShouldNotEqual, ProviderFactoryOf(LoggerProviderClass)())
+		})
+}
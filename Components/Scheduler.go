@@ -0,0 +1,453 @@
+package Components
+
+import (
+		"context"
+		"fmt"
+		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs"
+		"io"
+		"os"
+		"strings"
+		"sync"
+		"sync/atomic"
+		"text/tabwriter"
+		"time"
+)
+
+// 定时任务, ctx 在调度停止时 取消
+type ScheduleFunc func(ctx context.Context) error
+
+// 定时任务调度, 作为 schema 服务 由 Supervisor 监管运行
+// 服务提供器 在 Boot 中定义任务 (或者 Register 中, 需依赖 ScheduleProviderClass), eg:
+// scheduler := app.Get(Components.ScheduleProviderClass).(Components.Scheduler)
+// scheduler.Command("leaderboard:reset").Cron("0 0 * * 1").Timezone("Asia/Shanghai").WithoutOverlapping()
+type Scheduler interface {
+		SchemaService
+		Serve(ctx context.Context) error
+		Call(name string, fn ScheduleFunc) *ScheduledJob
+		Command(command string, args ...string) *ScheduledJob
+		Jobs() []*ScheduledJob
+}
+
+type ScheduleProvider interface {
+		Contracts.Provider
+		Scheduler
+}
+
+// 任务执行完成事件
+type ScheduleEvent struct {
+		Name     string
+		Start    time.Time
+		Duration time.Duration
+		Err      error
+}
+
+type ScheduleProviderImpl struct {
+		Name    string
+		app     Contracts.ApplicationContainer
+		clazz   Contracts.ClazzInterface
+		bean    Contracts.SupportInterface
+		jobs    []*ScheduledJob
+		changed chan struct{}
+		state   int32
+		wg      sync.WaitGroup
+		mutex   sync.RWMutex
+		now     func() time.Time
+		output  io.Writer
+}
+
+// 定时任务 定义, 默认每分钟运行
+type ScheduledJob struct {
+		name        string
+		description string
+		handler     ScheduleFunc
+		expr        string
+		schedule    Schedule
+		timezone    string
+		location    *time.Location
+		background  bool
+		running     int32
+		next        time.Time
+		scheduler   *ScheduleProviderImpl
+		// 上次未结束时 跳过
+		withoutOverlapping bool
+}
+
+const (
+		ScheduleProviderClass = "ScheduleProvider"
+		ScheduleListCommand   = "schedule:list"
+		ScheduleFinishedEvent = "schedule.finished"
+		scheduleDefaultCron   = "* * * * *"
+		scheduleTimeLayout    = "2006-01-02 15:04:05 -0700"
+)
+
+var (
+		scheduleInstanceLock sync.Once
+		scheduleInstance     *ScheduleProviderImpl
+)
+
+func ScheduleProviderOf() ScheduleProvider {
+		if scheduleInstance == nil {
+				scheduleInstanceLock.Do(scheduleProviderNew)
+		}
+		return scheduleInstance
+}
+
+func scheduleProviderNew() {
+		scheduleInstance = newScheduleProvider()
+}
+
+// 创建独立的调度服务 (非全局)
+func NewScheduleProvider() ScheduleProvider {
+		return newScheduleProvider()
+}
+
+func newScheduleProvider() *ScheduleProviderImpl {
+		var provider = new(ScheduleProviderImpl)
+		provider.Name = ScheduleProviderClass
+		provider.changed = make(chan struct{}, 1)
+		provider.now = time.Now
+		provider.output = os.Stdout
+		return provider
+}
+
+func (this *ScheduleProviderImpl) GetClazz() Contracts.ClazzInterface {
+		if this.clazz == nil {
+				this.clazz = ClazzOf(this)
+		}
+		return this.clazz
+}
+
+func (this *ScheduleProviderImpl) Init(app Contracts.ApplicationContainer) {
+		if this.app == nil {
+				this.app = app
+		}
+}
+
+func (this *ScheduleProviderImpl) Initializer(apps ...Contracts.ApplicationContainer) {
+		if len(apps) > 0 {
+				this.Init(apps[0])
+		}
+}
+
+func (this *ScheduleProviderImpl) GetSupportBean() Contracts.SupportInterface {
+		if this.bean == nil {
+				this.bean = BeanOf()
+		}
+		return this.bean
+}
+
+// 加入 schema 服务, 注册 schedule:list 命令
+func (this *ScheduleProviderImpl) Register() {
+		if !this.app.Exists(this.String()) {
+				this.app.Bind(this.String(), this)
+		}
+		if schemas, ok := this.app.Get(SchemaServiceProviderClass).(SchemaServiceProvider); ok {
+				schemas.Add(this)
+		}
+		if commander, ok := this.app.Get(CommandLineProviderClass).(Commander); ok {
+				_ = commander.Define(ScheduleListCommand, "list scheduled jobs with next run times", this.list)
+		}
+}
+
+func (this *ScheduleProviderImpl) Boot() {
+
+}
+
+// 调度依赖 schema 服务
+func (this *ScheduleProviderImpl) DependsOn() []string {
+		return []string{SchemaServiceProviderClass}
+}
+
+func (this *ScheduleProviderImpl) Constructor() interface{} {
+		return NewScheduleProvider()
+}
+
+func (this *ScheduleProviderImpl) Factory(app Contracts.ApplicationContainer) interface{} {
+		this.Init(app)
+		return this
+}
+
+func (this *ScheduleProviderImpl) String() string {
+		return this.Name
+}
+
+// 运行状态, 运行中 不再重复监管
+func (this *ScheduleProviderImpl) State() int {
+		return int(atomic.LoadInt32(&this.state))
+}
+
+// 定义 函数任务
+func (this *ScheduleProviderImpl) Call(name string, fn ScheduleFunc) *ScheduledJob {
+		return this.add(&ScheduledJob{name: name, handler: fn})
+}
+
+// 定义 控制台命令任务, 退出码非 0 视为失败
+func (this *ScheduleProviderImpl) Command(command string, args ...string) *ScheduledJob {
+		var job = &ScheduledJob{name: strings.Join(append([]string{command}, args...), " ")}
+		job.handler = func(ctx context.Context) error {
+				commander, ok := this.app.Get(CommandLineProviderClass).(Commander)
+				if !ok {
+						return fmt.Errorf("command %s: console commands unavailable", command)
+				}
+				if code := commander.Call(command, args, this.output); code != ExitSuccess {
+						return fmt.Errorf("command %s exited with code %d", command, code)
+				}
+				return nil
+		}
+		return this.add(job)
+}
+
+func (this *ScheduleProviderImpl) add(job *ScheduledJob) *ScheduledJob {
+		job.scheduler = this
+		job.Cron(scheduleDefaultCron)
+		this.mutex.Lock()
+		this.jobs = append(this.jobs, job)
+		this.mutex.Unlock()
+		this.notify()
+		return job
+}
+
+// 已定义的任务
+func (this *ScheduleProviderImpl) Jobs() []*ScheduledJob {
+		this.mutex.RLock()
+		defer this.mutex.RUnlock()
+		return append([]*ScheduledJob{}, this.jobs...)
+}
+
+// 任务变更, 重新计算下次运行时间
+func (this *ScheduleProviderImpl) notify() {
+		select {
+		case this.changed <- struct{}{}:
+		default:
+		}
+}
+
+// 调度运行, 阻塞到 ctx 结束, 等待运行中的任务 返回
+func (this *ScheduleProviderImpl) Serve(ctx context.Context) error {
+		atomic.StoreInt32(&this.state, ServiceRunning)
+		defer atomic.StoreInt32(&this.state, ServiceIdle)
+		defer this.wg.Wait()
+		for {
+				var (
+						timer *time.Timer
+						wait  <-chan time.Time
+				)
+				if next := this.upcoming(this.now()); !next.IsZero() {
+						timer = time.NewTimer(next.Sub(this.now()))
+						wait = timer.C
+				}
+				select {
+				case <-ctx.Done():
+				case <-this.changed:
+				case <-wait:
+						this.runDue(ctx, this.now())
+				}
+				if timer != nil {
+						timer.Stop()
+				}
+				if ctx.Err() != nil {
+						return nil
+				}
+		}
+}
+
+// 最近的运行时间, 无任务时 返回零值
+func (this *ScheduleProviderImpl) upcoming(now time.Time) time.Time {
+		var earliest time.Time
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		for _, job := range this.jobs {
+				if job.next.IsZero() {
+						job.next = job.Next(now)
+				}
+				if !job.next.IsZero() && (earliest.IsZero() || job.next.Before(earliest)) {
+						earliest = job.next
+				}
+		}
+		return earliest
+}
+
+// 运行到期的任务
+func (this *ScheduleProviderImpl) runDue(ctx context.Context, now time.Time) {
+		var due []*ScheduledJob
+		this.mutex.Lock()
+		for _, job := range this.jobs {
+				if !job.next.IsZero() && !job.next.After(now) {
+						job.next = job.Next(now)
+						due = append(due, job)
+				}
+		}
+		this.mutex.Unlock()
+		for _, job := range due {
+				this.dispatch(ctx, job)
+		}
+}
+
+// WithoutOverlapping 的任务 上次未结束时 跳过
+func (this *ScheduleProviderImpl) dispatch(ctx context.Context, job *ScheduledJob) {
+		if job.withoutOverlapping {
+				if !atomic.CompareAndSwapInt32(&job.running, 0, 1) {
+						return
+				}
+		} else {
+				atomic.AddInt32(&job.running, 1)
+		}
+		if !job.background {
+				this.execute(ctx, job)
+				return
+		}
+		this.wg.Add(1)
+		go func() {
+				defer this.wg.Done()
+				this.execute(ctx, job)
+		}()
+}
+
+// 执行任务, 发送完成事件 并记录失败日志
+func (this *ScheduleProviderImpl) execute(ctx context.Context, job *ScheduledJob) {
+		defer atomic.AddInt32(&job.running, -1)
+		var event = ScheduleEvent{Name: job.name, Start: this.now()}
+		event.Err = job.call(ctx)
+		event.Duration = this.now().Sub(event.Start)
+		if this.app == nil {
+				return
+		}
+		if event.Err != nil {
+				if logger, ok := this.app.Get(LoggerProviderClass).(LoggerProvider); ok {
+						logger.Error(fmt.Sprintf("schedule %s: %v", job.name, event.Err))
+				}
+		}
+		if dispatcher, ok := this.app.Get(EventBusProviderClass).(EventDispatcher); ok {
+				dispatcher.Dispatch(ScheduleFinishedEvent, event)
+		}
+}
+
+// schedule:list 命令, 列出任务 及下次运行时间
+func (this *ScheduleProviderImpl) list(input *ConsoleInput, out io.Writer) int {
+		this.mutex.RLock()
+		defer this.mutex.RUnlock()
+		if len(this.jobs) == 0 {
+				fmt.Fprintln(out, "no scheduled jobs")
+				return ExitSuccess
+		}
+		var (
+				now    = this.now()
+				writer = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		)
+		fmt.Fprintln(writer, "JOB\tSCHEDULE\tTIMEZONE\tNEXT RUN\tOPTIONS\tDESCRIPTION")
+		for _, job := range this.jobs {
+				var (
+						next    = "-"
+						zone    = job.timezone
+						options []string
+				)
+				if t := job.Next(now); !t.IsZero() {
+						next = t.Format(scheduleTimeLayout)
+				}
+				if zone == "" {
+						zone = "Local"
+				}
+				if job.withoutOverlapping {
+						options = append(options, "without-overlapping")
+				}
+				if job.background {
+						options = append(options, "background")
+				}
+				if len(options) == 0 {
+						options = append(options, "-")
+				}
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", job.name, job.expr, zone, next, strings.Join(options, ","), job.description)
+		}
+		_ = writer.Flush()
+		return ExitSuccess
+}
+
+// cron 表达式, 无效时 panic
+func (this *ScheduledJob) Cron(expr string) *ScheduledJob {
+		schedule, err := ParseCron(expr)
+		if err != nil {
+				panic(fmt.Errorf("schedule %s: %v", this.name, err))
+		}
+		return this.update(func() {
+				this.expr, this.schedule = expr, schedule
+		})
+}
+
+// 固定间隔
+func (this *ScheduledJob) Every(d time.Duration) *ScheduledJob {
+		if d <= 0 {
+				panic(fmt.Errorf("schedule %s: invalid interval %v", this.name, d))
+		}
+		var schedule = Every(d)
+		return this.update(func() {
+				this.expr, this.schedule = fmt.Sprint(schedule), schedule
+		})
+}
+
+// 按时区 计算运行时间, eg: Asia/Shanghai, 无效时 panic
+func (this *ScheduledJob) Timezone(timezone string) *ScheduledJob {
+		carbon, err := Libs.CarbonIn(timezone)
+		if err != nil {
+				panic(fmt.Errorf("schedule %s: invalid timezone %q: %v", this.name, timezone, err))
+		}
+		return this.update(func() {
+				this.timezone, this.location = timezone, carbon.Location()
+		})
+}
+
+// 上次运行未结束时 跳过本次
+func (this *ScheduledJob) WithoutOverlapping() *ScheduledJob {
+		return this.update(func() {
+				this.withoutOverlapping = true
+		})
+}
+
+// 后台运行, 不阻塞 其他到期任务
+func (this *ScheduledJob) RunInBackground() *ScheduledJob {
+		return this.update(func() {
+				this.background = true
+		})
+}
+
+// 任务说明, schedule:list 显示
+func (this *ScheduledJob) Description(description string) *ScheduledJob {
+		return this.update(func() {
+				this.description = description
+		})
+}
+
+func (this *ScheduledJob) update(fn func()) *ScheduledJob {
+		if this.scheduler == nil {
+				fn()
+				return this
+		}
+		this.scheduler.mutex.Lock()
+		fn()
+		this.next = time.Time{}
+		this.scheduler.mutex.Unlock()
+		this.scheduler.notify()
+		return this
+}
+
+// 下次运行时间, 按任务时区 计算
+func (this *ScheduledJob) Next(now time.Time) time.Time {
+		if this.location != nil {
+				now = now.In(this.location)
+		}
+		return this.schedule.Next(now)
+}
+
+// 执行任务, panic 视为失败
+func (this *ScheduledJob) call(ctx context.Context) (err error) {
+		defer func() {
+				if r := recover(); r != nil {
+						err = fmt.Errorf("panic: %v", r)
+				}
+		}()
+		return this.handler(ctx)
+}
+
+func (this *ScheduledJob) String() string {
+		return this.name
+}
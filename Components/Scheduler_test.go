@@ -0,0 +1,180 @@
+package Components
+
+import (
+		"bytes"
+		"context"
+		"errors"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"io"
+		"sync"
+		"sync/atomic"
+		"testing"
+		"time"
+)
+
+type scheduleApp struct {
+		Contracts.ApplicationContainer
+		objects map[string]interface{}
+}
+
+func (this *scheduleApp) Get(id string) interface{} {
+		return this.objects[id]
+}
+
+func (this *scheduleApp) Exists(id string) bool {
+		_, ok := this.objects[id]
+		return ok
+}
+
+func (this *scheduleApp) Bind(id string, obj interface{}) {
+		this.objects[id] = obj
+}
+
+func newTestScheduleApp() (*scheduleApp, *ScheduleProviderImpl) {
+		var (
+				app       = &scheduleApp{objects: make(map[string]interface{})}
+				schemas   = SchemaServiceProviderOf()
+				scheduler = newScheduleProvider()
+		)
+		schemas.Init(app)
+		app.objects[SchemaServiceProviderClass] = schemas
+		app.objects[CommandLineProviderClass] = new(CommanderImpl)
+		app.objects[EventBusProviderClass] = newTestEventBus()
+		scheduler.Init(app)
+		scheduler.Register()
+		return app, scheduler
+}
+
+func TestScheduleProviderRegister(t *testing.T) {
+		app, scheduler := newTestScheduleApp()
+		Convey("Schedule Provider Register Test", t, func() {
+				So(app.Get(ScheduleProviderClass), ShouldEqual, scheduler)
+				So(app.Get(SchemaServiceProviderClass).(SchemaServiceProvider).Schemas(), ShouldContain, scheduler)
+				So(scheduler.DependsOn(), ShouldResemble, []string{SchemaServiceProviderClass})
+
+				var buf bytes.Buffer
+				commander := app.Get(CommandLineProviderClass).(Commander)
+				So(commander.Call(ScheduleListCommand, nil, &buf), ShouldEqual, ExitSuccess)
+				So(buf.String(), ShouldEqual, "no scheduled jobs\n")
+
+				scheduler.now = func() time.Time {
+						return time.Date(2024, 1, 15, 10, 7, 30, 0, time.UTC)
+				}
+				scheduler.Call("stats:rollup", func(ctx context.Context) error {
+						return nil
+				}).Cron("0 * * * *").Description("hourly stats").RunInBackground()
+				scheduler.Command("leaderboard:reset").Cron("0 0 * * 1").Timezone("Asia/Shanghai").WithoutOverlapping()
+				scheduler.Call("cleanup", nil).Every(time.Minute)
+				buf.Reset()
+				So(commander.Call(ScheduleListCommand, nil, &buf), ShouldEqual, ExitSuccess)
+				So(buf.String(), ShouldContainSubstring, "JOB                SCHEDULE     TIMEZONE       NEXT RUN")
+				So(buf.String(), ShouldContainSubstring, "stats:rollup       0 * * * *    Local")
+				So(buf.String(), ShouldContainSubstring, "background           hourly stats")
+				So(buf.String(), ShouldContainSubstring, "leaderboard:reset  0 0 * * 1    Asia/Shanghai  2024-01-22 00:00:00 +0800  without-overlapping")
+				So(buf.String(), ShouldContainSubstring, "cleanup            @every 1m0s")
+		})
+}
+
+func TestScheduledJob(t *testing.T) {
+		_, scheduler := newTestScheduleApp()
+		Convey("Scheduled Job Test", t, func() {
+				So(func() { scheduler.Call("bad", nil).Cron("* * *") }, ShouldPanic)
+				So(func() { scheduler.Call("bad", nil).Timezone("Mars/Olympus") }, ShouldPanic)
+				So(func() { scheduler.Call("bad", nil).Every(0) }, ShouldPanic)
+				So(scheduler.Call("default", nil).expr, ShouldEqual, scheduleDefaultCron)
+		})
+}
+
+func TestScheduleRunDue(t *testing.T) {
+		app, scheduler := newTestScheduleApp()
+		var (
+				now      = time.Date(2024, 1, 15, 10, 7, 30, 0, time.UTC)
+				ctx      = context.Background()
+				runs     int32
+				release  = make(chan struct{})
+				events   []ScheduleEvent
+				mutex    sync.Mutex
+				commands int32
+		)
+		app.Get(EventBusProviderClass).(EventDispatcher).Listen(ScheduleFinishedEvent, func(event string, payload interface{}) {
+				mutex.Lock()
+				events = append(events, payload.(ScheduleEvent))
+				mutex.Unlock()
+		})
+		_ = app.Get(CommandLineProviderClass).(Commander).Define("leaderboard:reset {--fail}", "", func(input *ConsoleInput, out io.Writer) int {
+				atomic.AddInt32(&commands, 1)
+				if input.Bool("fail") {
+						return ExitFailure
+				}
+				return ExitSuccess
+		})
+		scheduler.Call("slow", func(ctx context.Context) error {
+				atomic.AddInt32(&runs, 1)
+				<-release
+				return nil
+		}).Cron("*/5 * * * *").RunInBackground().WithoutOverlapping()
+		scheduler.Call("broken", func(ctx context.Context) error {
+				panic("boom")
+		}).Cron("0 * * * *")
+		scheduler.Command("leaderboard:reset", "--fail").Cron("0 * * * *")
+		Convey("Schedule Run Due Test", t, func() {
+				So(scheduler.upcoming(now), ShouldEqual, now.Add(150*time.Second))
+				scheduler.runDue(ctx, now)
+				So(atomic.LoadInt32(&runs), ShouldEqual, 0)
+
+				// 10:10 slow 到期, 后台运行
+				scheduler.runDue(ctx, now.Add(150*time.Second))
+				So(scheduler.upcoming(now), ShouldEqual, now.Add(450*time.Second))
+				// 10:15 slow 仍在运行, 跳过
+				for atomic.LoadInt32(&runs) == 0 {
+						time.Sleep(time.Millisecond)
+				}
+				scheduler.runDue(ctx, now.Add(450*time.Second))
+				close(release)
+				scheduler.wg.Wait()
+				So(atomic.LoadInt32(&runs), ShouldEqual, 1)
+
+				// 11:00 slow 再次运行, broken 及命令任务 失败
+				scheduler.runDue(ctx, now.Add(time.Hour))
+				scheduler.wg.Wait()
+				So(atomic.LoadInt32(&runs), ShouldEqual, 2)
+				So(atomic.LoadInt32(&commands), ShouldEqual, 1)
+				mutex.Lock()
+				defer mutex.Unlock()
+				var failures = make(map[string]error)
+				for _, event := range events {
+						failures[event.Name] = event.Err
+				}
+				So(len(events), ShouldEqual, 4)
+				So(failures["slow"], ShouldBeNil)
+				So(failures["broken"], ShouldResemble, errors.New("panic: boom"))
+				So(failures["leaderboard:reset --fail"], ShouldResemble, errors.New("command leaderboard:reset exited with code 1"))
+		})
+}
+
+func TestScheduleServe(t *testing.T) {
+		_, scheduler := newTestScheduleApp()
+		var (
+				runs        int32
+				ctx, cancel = context.WithCancel(context.Background())
+				done        = make(chan error, 1)
+		)
+		Convey("Schedule Serve Test", t, func() {
+				go func() {
+						done <- scheduler.Serve(ctx)
+				}()
+				// 运行中 定义的任务 立即生效
+				scheduler.Call("tick", func(ctx context.Context) error {
+						atomic.AddInt32(&runs, 1)
+						return nil
+				}).Every(10 * time.Millisecond)
+				for atomic.LoadInt32(&runs) < 3 {
+						time.Sleep(time.Millisecond)
+				}
+				So(scheduler.State(), ShouldEqual, ServiceRunning)
+				cancel()
+				So(<-done, ShouldBeNil)
+				So(scheduler.State(), ShouldEqual, ServiceIdle)
+		})
+}
@@ -65,23 +65,38 @@ func (this *SchemaServiceProviderImpl) Register() {
 		if !this.app.Exists(this.String()) {
 				this.app.Bind(this.String(), this)
 		}
+		// 服务监管, 应用停止前 先停止监管的服务
+		this.app.Singleton(SupervisorClass, func(Contracts.ApplicationContainer) interface{} {
+				var supervisor = NewSupervisor(this.app)
+				this.app.Terminating(func(Contracts.ApplicationContainer) {
+						supervisor.Stop()
+				})
+				return supervisor
+		})
 }
 
+// 并发启动全部 schema, 由 Supervisor 按重启策略监管
 func (this *SchemaServiceProviderImpl) Boot() {
+		if RunningCommand(this.app) {
+				return
+		}
+		supervisor, ok := this.app.Get(SupervisorClass).(Supervisor)
+		if !ok {
+				return
+		}
 		for _, schema := range this.Schemas() {
 				if schema.State() <= 0 {
 						schema.Initializer(this.app)
-						starter, ok := schema.(Contracts.Starter)
-						if !ok {
+						if service, ok := schema.(Serviceable); ok {
+								supervisor.Supervise(service)
 								continue
 						}
-						if starter.Block() {
-								go starter.StartUp()
-						} else {
-								starter.StartUp()
+						if starter, ok := schema.(Contracts.Starter); ok {
+								supervisor.Supervise(StarterOf(starter))
 						}
 				}
 		}
+		supervisor.Start()
 }
 
 func (this *SchemaServiceProviderImpl) Schemas() []SchemaService {
@@ -0,0 +1,61 @@
+package Components
+
+import (
+		"context"
+		"github.com/hashicorp/go-uuid"
+		"github.com/webGameLinux/kits/Contracts"
+		"net/http"
+)
+
+type scopeContextKey struct{}
+
+const (
+		RequestAlias       = "request"
+		RequestIdAlias     = "request.id"
+		RequestLoggerAlias = "request.logger"
+		RequestIdHeader    = "X-Request-Id"
+)
+
+// 请求作用域中间件
+// 每个请求 创建作用域, 绑定 request 和 request.id (关联id), 请求结束时 销毁作用域
+func ScopeMiddleware(app Contracts.ApplicationContainer) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+						var (
+								scope = app.NewScope()
+								id    = RequestId(request)
+						)
+						defer scope.End()
+						request = request.WithContext(WithScope(request.Context(), scope))
+						scope.Bind(RequestAlias, request)
+						scope.Bind(RequestIdAlias, id)
+						writer.Header().Set(RequestIdHeader, id)
+						next.ServeHTTP(writer, request)
+				})
+		}
+}
+
+// 保存作用域到 context
+func WithScope(ctx context.Context, scope Contracts.ScopeContainer) context.Context {
+		return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// 获取 context 中的作用域, 不存在时 返回 nil
+func ScopeOf(ctx context.Context) Contracts.ScopeContainer {
+		if ctx == nil {
+				return nil
+		}
+		if scope, ok := ctx.Value(scopeContextKey{}).(Contracts.ScopeContainer); ok {
+				return scope
+		}
+		return nil
+}
+
+// 请求关联id, 优先使用请求头 X-Request-Id
+func RequestId(request *http.Request) string {
+		if id := request.Header.Get(RequestIdHeader); id != "" {
+				return id
+		}
+		id, _ := uuid.GenerateUUID()
+		return id
+}
@@ -0,0 +1,66 @@
+package Components
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"net/http"
+		"net/http/httptest"
+		"testing"
+)
+
+type testScope struct {
+		Contracts.ScopeContainer
+		binds map[string]interface{}
+		ended bool
+}
+
+type testScopeApp struct {
+		Contracts.ApplicationContainer
+		scopes []*testScope
+}
+
+func (this *testScope) Bind(id string, obj interface{}) {
+		this.binds[id] = obj
+}
+
+func (this *testScope) Get(id string) interface{} {
+		return this.binds[id]
+}
+
+func (this *testScope) End() {
+		this.ended = true
+}
+
+func (this *testScopeApp) NewScope() Contracts.ScopeContainer {
+		scope := &testScope{binds: make(map[string]interface{})}
+		this.scopes = append(this.scopes, scope)
+		return scope
+}
+
+func TestScopeMiddleware(t *testing.T) {
+		var (
+				app     = new(testScopeApp)
+				current Contracts.ScopeContainer
+		)
+		handler := ScopeMiddleware(app)(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				current = ScopeOf(request.Context())
+		}))
+		Convey("Scope Middleware Test", t, func() {
+				recorder := httptest.NewRecorder()
+				request := httptest.NewRequest(http.MethodGet, "/", nil)
+				request.Header.Set(RequestIdHeader, "abc")
+				handler.ServeHTTP(recorder, request)
+				So(current, ShouldEqual, app.scopes[0])
+				So(current.Get(RequestIdAlias), ShouldEqual, "abc")
+				So(current.Get(RequestAlias), ShouldNotBeNil)
+				So(app.scopes[0].ended, ShouldBeTrue)
+				So(recorder.Header().Get(RequestIdHeader), ShouldEqual, "abc")
+
+				recorder = httptest.NewRecorder()
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+				So(len(app.scopes), ShouldEqual, 2)
+				So(app.scopes[1].Get(RequestIdAlias), ShouldNotBeEmpty)
+				So(recorder.Header().Get(RequestIdHeader), ShouldEqual, app.scopes[1].Get(RequestIdAlias))
+				So(ScopeOf(request.Context()), ShouldBeNil)
+		})
+}
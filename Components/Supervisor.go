@@ -0,0 +1,399 @@
+package Components
+
+import (
+		"context"
+		"errors"
+		"fmt"
+		"github.com/webGameLinux/kits/Contracts"
+		"sort"
+		"sync"
+		"time"
+)
+
+// 可监管的服务, Serve 阻塞运行 直到 ctx 结束; 返回 error 或者 panic 视为失败
+type Serviceable interface {
+		fmt.Stringer
+		Serve(ctx context.Context) error
+}
+
+// 服务自定义重启策略
+type RestartPolicyInterface interface {
+		RestartPolicy() RestartPolicy
+}
+
+// 重启策略
+type RestartPolicy struct {
+		// never | on-failure | always
+		Mode string
+		// 首次重启等待, 之后按指数增长, 未设置时 使用默认值
+		Backoff    time.Duration
+		MaxBackoff time.Duration
+		// on-failure 最大连续重启次数, 0 不限; 稳定运行超过 MaxBackoff 后 重新计数
+		MaxRetries int
+}
+
+// 服务状态变更事件
+type ServiceStateEvent struct {
+		Name     string
+		From     int
+		To       int
+		Restarts int
+		Err      error
+}
+
+// 服务监管
+type Supervisor interface {
+		Supervise(service Serviceable, policy ...RestartPolicy)
+		Start()
+		Stop()
+		State(name string) int
+		States() map[string]int
+		Names() []string
+}
+
+type supervisedService struct {
+		service  Serviceable
+		policy   RestartPolicy
+		state    int
+		restarts int
+		err      error
+}
+
+type SupervisorImpl struct {
+		app      Contracts.ApplicationContainer
+		services map[string]*supervisedService
+		names    []string
+		ctx      context.Context
+		cancel   context.CancelFunc
+		started  bool
+		wg       sync.WaitGroup
+		mutex    sync.RWMutex
+}
+
+const (
+		SupervisorClass             = "Supervisor"
+		SupervisorStateEvent        = "supervisor.state"
+		SupervisorHealthPrefix      = "schema."
+		SupervisorRestartConfig     = "schema.%s.restart"
+		SupervisorBackoffDefault    = time.Second
+		SupervisorMaxBackoffDefault = 30 * time.Second
+		RestartNever                = "never"
+		RestartOnFailure            = "on-failure"
+		RestartAlways               = "always"
+)
+
+// 服务状态
+const (
+		ServiceIdle = iota
+		ServiceStarting
+		ServiceRunning
+		ServiceBackoff
+		ServiceStopped
+		ServiceFailed
+)
+
+var serviceStateNames = map[int]string{
+		ServiceIdle:     "idle",
+		ServiceStarting: "starting",
+		ServiceRunning:  "running",
+		ServiceBackoff:  "backoff",
+		ServiceStopped:  "stopped",
+		ServiceFailed:   "failed",
+}
+
+// 状态名称
+func ServiceStateName(state int) string {
+		if name, ok := serviceStateNames[state]; ok {
+				return name
+		}
+		return fmt.Sprintf("unknown(%d)", state)
+}
+
+// 默认退避参数的重启策略, 未知模式 按 on-failure
+func RestartPolicyOf(mode string) RestartPolicy {
+		switch mode {
+		case RestartNever, RestartAlways:
+		default:
+				mode = RestartOnFailure
+		}
+		return RestartPolicy{Mode: mode, Backoff: SupervisorBackoffDefault, MaxBackoff: SupervisorMaxBackoffDefault}
+}
+
+// 未设置的退避参数 使用默认值
+func (this RestartPolicy) withDefaults() RestartPolicy {
+		if this.Backoff <= 0 {
+				this.Backoff = SupervisorBackoffDefault
+		}
+		if this.MaxBackoff <= 0 {
+				this.MaxBackoff = SupervisorMaxBackoffDefault
+		}
+		if this.MaxBackoff < this.Backoff {
+				this.MaxBackoff = this.Backoff
+		}
+		return this
+}
+
+// 创建服务监管
+func NewSupervisor(app Contracts.ApplicationContainer) Supervisor {
+		var supervisor = new(SupervisorImpl)
+		supervisor.app = app
+		supervisor.services = make(map[string]*supervisedService)
+		supervisor.ctx, supervisor.cancel = context.WithCancel(context.Background())
+		return supervisor
+}
+
+// 添加监管服务, 同名忽略; 已启动时 立即运行
+// 未指定策略时 依次使用 RestartPolicyInterface, 配置 schema.<name>.restart, on-failure
+func (this *SupervisorImpl) Supervise(service Serviceable, policy ...RestartPolicy) {
+		if service == nil {
+				return
+		}
+		var name = service.String()
+		if len(policy) == 0 {
+				policy = append(policy, this.policy(service))
+		}
+		this.mutex.Lock()
+		if _, ok := this.services[name]; ok {
+				this.mutex.Unlock()
+				return
+		}
+		var unit = &supervisedService{service: service, policy: policy[0].withDefaults()}
+		this.services[name] = unit
+		this.names = append(this.names, name)
+		var started = this.started && this.ctx.Err() == nil
+		if started {
+				this.wg.Add(1)
+		}
+		this.mutex.Unlock()
+		this.health(name)
+		if started {
+				go this.run(unit)
+		}
+}
+
+// 并发启动全部服务, 重复调用无效
+func (this *SupervisorImpl) Start() {
+		this.mutex.Lock()
+		if this.started || this.ctx.Err() != nil {
+				this.mutex.Unlock()
+				return
+		}
+		this.started = true
+		var units []*supervisedService
+		for _, name := range this.names {
+				units = append(units, this.services[name])
+		}
+		this.wg.Add(len(units))
+		this.mutex.Unlock()
+		for _, unit := range units {
+				go this.run(unit)
+		}
+}
+
+// 停止全部服务 并等待退出
+func (this *SupervisorImpl) Stop() {
+		this.cancel()
+		this.wg.Wait()
+}
+
+// 应用销毁时 停止
+func (this *SupervisorImpl) Destroy() {
+		this.Stop()
+}
+
+func (this *SupervisorImpl) State(name string) int {
+		this.mutex.RLock()
+		defer this.mutex.RUnlock()
+		if unit, ok := this.services[name]; ok {
+				return unit.state
+		}
+		return ServiceIdle
+}
+
+func (this *SupervisorImpl) States() map[string]int {
+		var states = make(map[string]int)
+		this.mutex.RLock()
+		defer this.mutex.RUnlock()
+		for name, unit := range this.services {
+				states[name] = unit.state
+		}
+		return states
+}
+
+func (this *SupervisorImpl) Names() []string {
+		this.mutex.RLock()
+		var names = append([]string{}, this.names...)
+		this.mutex.RUnlock()
+		sort.Strings(names)
+		return names
+}
+
+// 运行 直到停止 或者 按策略不再重启
+func (this *SupervisorImpl) run(unit *supervisedService) {
+		defer this.wg.Done()
+		var backoff = unit.policy.Backoff
+		for {
+				this.transit(unit, ServiceStarting, nil)
+				var (
+						begin = time.Now()
+						err   = this.serve(unit)
+				)
+				if this.ctx.Err() != nil {
+						this.transit(unit, ServiceStopped, nil)
+						return
+				}
+				// 稳定运行超过最大退避 重置退避 和 连续重启次数
+				if time.Since(begin) > unit.policy.MaxBackoff {
+						backoff = unit.policy.Backoff
+						this.mutex.Lock()
+						unit.restarts = 0
+						this.mutex.Unlock()
+				}
+				if !this.restartable(unit, err) {
+						if err != nil {
+								this.transit(unit, ServiceFailed, err)
+						} else {
+								this.transit(unit, ServiceStopped, nil)
+						}
+						return
+				}
+				this.transit(unit, ServiceBackoff, err)
+				select {
+				case <-this.ctx.Done():
+						this.transit(unit, ServiceStopped, nil)
+						return
+				case <-time.After(backoff):
+				}
+				if backoff *= 2; backoff > unit.policy.MaxBackoff {
+						backoff = unit.policy.MaxBackoff
+				}
+				this.mutex.Lock()
+				unit.restarts++
+				this.mutex.Unlock()
+		}
+}
+
+// 运行一次, panic 转为 error
+func (this *SupervisorImpl) serve(unit *supervisedService) (err error) {
+		defer func() {
+				if e := recover(); e != nil {
+						err = fmt.Errorf("%s panic: %v", unit.service.String(), e)
+				}
+		}()
+		this.transit(unit, ServiceRunning, nil)
+		return unit.service.Serve(this.ctx)
+}
+
+// 是否按策略重启
+func (this *SupervisorImpl) restartable(unit *supervisedService, err error) bool {
+		switch unit.policy.Mode {
+		case RestartAlways:
+				return true
+		case RestartOnFailure:
+				if err == nil {
+						return false
+				}
+				this.mutex.RLock()
+				defer this.mutex.RUnlock()
+				return unit.policy.MaxRetries <= 0 || unit.restarts < unit.policy.MaxRetries
+		}
+		return false
+}
+
+// 状态变更, 发送事件 并记录失败日志
+func (this *SupervisorImpl) transit(unit *supervisedService, state int, err error) {
+		this.mutex.Lock()
+		var event = ServiceStateEvent{Name: unit.service.String(), From: unit.state, To: state, Restarts: unit.restarts, Err: err}
+		unit.state = state
+		unit.err = err
+		this.mutex.Unlock()
+		if err != nil {
+				if logger, ok := this.app.Get(LoggerProviderClass).(LoggerProvider); ok {
+						logger.Error(fmt.Sprintf("%s %s: %v", event.Name, ServiceStateName(state), err))
+				}
+		}
+		if dispatcher, ok := this.app.Get(EventBusProviderClass).(EventDispatcher); ok {
+				dispatcher.Dispatch(SupervisorStateEvent, event)
+		}
+}
+
+// 注册健康检查 schema.<name>, 退避或者失败时 不健康
+func (this *SupervisorImpl) health(name string) {
+		registry, ok := this.app.Get(HealthRegistryProviderClass).(HealthRegistry)
+		if !ok {
+				return
+		}
+		registry.Add(SupervisorHealthPrefix+name, func(ctx context.Context) (map[string]interface{}, error) {
+				this.mutex.RLock()
+				unit := this.services[name]
+				var (
+						state   = unit.state
+						details = map[string]interface{}{"state": ServiceStateName(state), "restarts": unit.restarts}
+						err     = unit.err
+				)
+				this.mutex.RUnlock()
+				if state == ServiceBackoff || state == ServiceFailed {
+						if err == nil {
+								err = errors.New(name + " " + ServiceStateName(state))
+						}
+						return details, err
+				}
+				return details, nil
+		})
+}
+
+// 服务重启策略
+func (this *SupervisorImpl) policy(service Serviceable) RestartPolicy {
+		if it, ok := service.(RestartPolicyInterface); ok {
+				return it.RestartPolicy()
+		}
+		if config, ok := this.app.Get(ConfigureProviderClass).(ConfigureProvider); ok {
+				return RestartPolicyOf(config.Get(fmt.Sprintf(SupervisorRestartConfig, service.String())))
+		}
+		return RestartPolicyOf(RestartOnFailure)
+}
+
+// Contracts.Starter 适配为可监管服务
+// 阻塞型 StartUp 返回即退出, 非阻塞型 运行到 ctx 结束; ctx 结束时 调用 Stop
+func StarterOf(starter Contracts.Starter) Serviceable {
+		return &starterService{starter: starter}
+}
+
+type starterService struct {
+		starter Contracts.Starter
+}
+
+func (this *starterService) String() string {
+		if name, ok := this.starter.(fmt.Stringer); ok {
+				return name.String()
+		}
+		return fmt.Sprintf("%T", this.starter)
+}
+
+func (this *starterService) Serve(ctx context.Context) error {
+		var done = make(chan error, 1)
+		go func() {
+				defer func() {
+						if e := recover(); e != nil {
+								done <- fmt.Errorf("%s panic: %v", this.String(), e)
+						}
+				}()
+				this.starter.StartUp()
+				done <- nil
+		}()
+		if !this.starter.Block() {
+				if err := <-done; err != nil {
+						return err
+				}
+				<-ctx.Done()
+				this.starter.Stop()
+				return nil
+		}
+		select {
+		case err := <-done:
+				return err
+		case <-ctx.Done():
+				this.starter.Stop()
+				return <-done
+		}
+}
@@ -0,0 +1,197 @@
+package Components
+
+import (
+		"context"
+		"errors"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"sync"
+		"sync/atomic"
+		"testing"
+		"time"
+)
+
+type supervisorApp struct {
+		Contracts.ApplicationContainer
+		objects map[string]interface{}
+}
+
+func (this *supervisorApp) Get(id string) interface{} {
+		return this.objects[id]
+}
+
+type flakyService struct {
+		name     string
+		failures int32
+		runs     int32
+}
+
+func (this *flakyService) String() string {
+		return this.name
+}
+
+func (this *flakyService) Serve(ctx context.Context) error {
+		switch atomic.AddInt32(&this.runs, 1) {
+		case 1:
+				panic("boom")
+		case 2:
+				return errors.New("listen failed")
+		}
+		if atomic.LoadInt32(&this.runs) <= this.failures {
+				return errors.New("listen failed")
+		}
+		<-ctx.Done()
+		return nil
+}
+
+type blockStarter struct {
+		stop    chan struct{}
+		stopped int32
+}
+
+func (this *blockStarter) StartUp() {
+		<-this.stop
+}
+
+func (this *blockStarter) Stop() {
+		atomic.StoreInt32(&this.stopped, 1)
+		close(this.stop)
+}
+
+func (this *blockStarter) Block() bool {
+		return true
+}
+
+func (this *blockStarter) State() int {
+		return 0
+}
+
+func (this *blockStarter) Initializer(...Contracts.ApplicationContainer) {
+}
+
+func (this *blockStarter) String() string {
+		return "BlockStarter"
+}
+
+func TestSupervisor(t *testing.T) {
+		var (
+				bus      = newTestEventBus()
+				registry = newTestHealthRegistry()
+				app      = &supervisorApp{objects: map[string]interface{}{
+						EventBusProviderClass:       bus,
+						HealthRegistryProviderClass: registry,
+				}}
+				supervisor = NewSupervisor(app)
+				policy     = RestartPolicy{Mode: RestartOnFailure, Backoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+				flaky      = &flakyService{name: "flaky", failures: 4}
+				never      = &flakyService{name: "never"}
+				always     = &flakyService{name: "always", failures: 100}
+				starter    = &blockStarter{stop: make(chan struct{})}
+				events     []ServiceStateEvent
+				mutex      sync.Mutex
+		)
+		bus.Listen(SupervisorStateEvent, func(event string, payload interface{}) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				events = append(events, payload.(ServiceStateEvent))
+		})
+		supervisor.Supervise(flaky, policy)
+		supervisor.Supervise(never, RestartPolicy{Mode: RestartNever})
+		supervisor.Supervise(StarterOf(starter))
+		supervisor.Start()
+		supervisor.Supervise(always, RestartPolicy{Mode: RestartAlways, Backoff: time.Millisecond, MaxBackoff: time.Millisecond})
+		Convey("Supervisor Test", t, func() {
+				So(waitFor(func() bool { return supervisor.State("flaky") == ServiceRunning && atomic.LoadInt32(&flaky.runs) == 5 }), ShouldBeTrue)
+				So(waitFor(func() bool { return supervisor.State("never") == ServiceFailed }), ShouldBeTrue)
+				So(waitFor(func() bool { return atomic.LoadInt32(&always.runs) >= 5 }), ShouldBeTrue)
+				So(supervisor.State("BlockStarter"), ShouldEqual, ServiceRunning)
+				So(supervisor.Names(), ShouldResemble, []string{"BlockStarter", "always", "flaky", "never"})
+
+				report := registry.Run()
+				So(report.Status, ShouldEqual, HealthDown)
+				for _, check := range report.Checks {
+						if check.Name == SupervisorHealthPrefix+"flaky" {
+								So(check.Status, ShouldEqual, HealthUp)
+								So(check.Details["restarts"], ShouldEqual, 4)
+						}
+						if check.Name == SupervisorHealthPrefix+"never" {
+								So(check.Status, ShouldEqual, HealthDown)
+								So(check.Error, ShouldContainSubstring, "panic: boom")
+						}
+				}
+
+				supervisor.Stop()
+				So(atomic.LoadInt32(&starter.stopped), ShouldEqual, 1)
+				for _, state := range supervisor.States() {
+						So(state, ShouldBeIn, ServiceStopped, ServiceFailed)
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				So(events[0].From, ShouldEqual, ServiceIdle)
+				So(events[0].To, ShouldEqual, ServiceStarting)
+		})
+}
+
+// 连续失败后 稳定运行一段时间 再失败
+type stableService struct {
+		runs int32
+}
+
+func (this *stableService) String() string {
+		return "stable"
+}
+
+func (this *stableService) Serve(ctx context.Context) error {
+		switch atomic.AddInt32(&this.runs, 1) {
+		case 3:
+				time.Sleep(20 * time.Millisecond)
+		case 5:
+				<-ctx.Done()
+				return nil
+		}
+		return errors.New("listen failed")
+}
+
+func TestSupervisorMaxRetries(t *testing.T) {
+		var (
+				app        = &supervisorApp{objects: map[string]interface{}{}}
+				supervisor = NewSupervisor(app)
+				stable     = &stableService{}
+				flaky      = &flakyService{name: "flaky", failures: 100}
+		)
+		supervisor.Supervise(stable, RestartPolicy{Mode: RestartOnFailure, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, MaxRetries: 2})
+		supervisor.Supervise(flaky, RestartPolicy{Mode: RestartOnFailure, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, MaxRetries: 2})
+		supervisor.Start()
+		defer supervisor.Stop()
+		Convey("Supervisor Max Retries Test", t, func() {
+				// 稳定运行后 重新计数
+				So(waitFor(func() bool {
+						return supervisor.State("stable") == ServiceRunning && atomic.LoadInt32(&stable.runs) == 5
+				}), ShouldBeTrue)
+				So(waitFor(func() bool { return supervisor.State("flaky") == ServiceFailed }), ShouldBeTrue)
+				So(atomic.LoadInt32(&flaky.runs), ShouldEqual, 3)
+		})
+}
+
+func TestRestartPolicyOf(t *testing.T) {
+		Convey("Restart Policy Test", t, func() {
+				policy := RestartPolicy{Mode: RestartAlways}.withDefaults()
+				So(policy.Backoff, ShouldEqual, SupervisorBackoffDefault)
+				So(policy.MaxBackoff, ShouldEqual, SupervisorMaxBackoffDefault)
+				policy = RestartPolicy{Backoff: time.Minute}.withDefaults()
+				So(policy.MaxBackoff, ShouldEqual, time.Minute)
+				So(RestartPolicyOf("").Mode, ShouldEqual, RestartOnFailure)
+				So(RestartPolicyOf(RestartAlways).Mode, ShouldEqual, RestartAlways)
+				So(RestartPolicyOf(RestartNever).Backoff, ShouldEqual, SupervisorBackoffDefault)
+				So(ServiceStateName(ServiceBackoff), ShouldEqual, "backoff")
+		})
+}
+
+func waitFor(fn func() bool) bool {
+		for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+				if fn() {
+						return true
+				}
+		}
+		return false
+}
@@ -0,0 +1,35 @@
+package Components
+
+import (
+		"github.com/webGameLinux/kits/Contracts"
+		"net/http"
+)
+
+const (
+		VersionPath = "/version"
+)
+
+// 构建信息 属性 => 输出字段
+var versionFields = [][2]string{
+		{Contracts.AppNameKey, "app_name"},
+		{Contracts.AppVersion, "version"},
+		{Contracts.AppGitCommit, "git_commit"},
+		{Contracts.AppBuildTime, "build_time"},
+		{Contracts.AppGoVersion, "go_version"},
+}
+
+// 版本信息, 取自应用属性 (Profiles)
+func VersionInfo(app Contracts.ApplicationContainer) map[string]interface{} {
+		var info = make(map[string]interface{})
+		for _, field := range versionFields {
+				info[field[1]] = app.GetProfile(field[0])
+		}
+		return info
+}
+
+// GET /version 输出构建信息
+func VersionHandler(app Contracts.ApplicationContainer) http.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) {
+				writeHealth(writer, http.StatusOK, VersionInfo(app))
+		}
+}
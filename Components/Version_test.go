@@ -0,0 +1,40 @@
+package Components
+
+import (
+		"encoding/json"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"net/http"
+		"net/http/httptest"
+		"testing"
+)
+
+type versionApp struct {
+		Contracts.ApplicationContainer
+		profiles map[string]interface{}
+}
+
+func (this *versionApp) GetProfile(key string) interface{} {
+		return this.profiles[key]
+}
+
+func TestVersionHandler(t *testing.T) {
+		var app = &versionApp{profiles: map[string]interface{}{
+				Contracts.AppNameKey:   "game",
+				Contracts.AppVersion:   "1.2.0",
+				Contracts.AppGitCommit: "a1b2c3d",
+		}}
+		Convey("Version Handler Test", t, func() {
+				var (
+						recorder = httptest.NewRecorder()
+						body     map[string]interface{}
+				)
+				VersionHandler(app)(recorder, httptest.NewRequest(http.MethodGet, VersionPath, nil))
+				So(recorder.Code, ShouldEqual, http.StatusOK)
+				So(json.Unmarshal(recorder.Body.Bytes(), &body), ShouldBeNil)
+				So(body["app_name"], ShouldEqual, "game")
+				So(body["version"], ShouldEqual, "1.2.0")
+				So(body["git_commit"], ShouldEqual, "a1b2c3d")
+				So(body["build_time"], ShouldBeNil)
+		})
+}
@@ -10,10 +10,12 @@ type Application interface {
 		IocInit()
 		PropsInit()
 		InitCoreProviders()
-		InitRegisters()
-		InitBoots()
+		InitRegisters() error
+		InitBoots() error
 		StarUp()
 		Stop()
+		Reload()
+		Emit(string, interface{})
 		Profiles() map[string]interface{}
 		GetProfile(string) interface{}
 }
@@ -22,6 +24,11 @@ type DestroyInterface interface {
 		Destroy()
 }
 
+// 可重新加载 (配置,环境变量)
+type ReloadInterface interface {
+		Reload()
+}
+
 type Container interface {
 		Get(string) interface{}
 		Alias(string, string)
@@ -30,16 +37,47 @@ type Container interface {
 		Destroy(...string)
 		Keys() []string
 		Exists(string) bool
+		Tag([]string, ...string)
+		Tagged(string) []string
 }
 
 type ApplicationContainer interface {
 		Application
 		Get(string) interface{}
+		Resolve(string) (interface{}, error)
 		Register(Provider)
 		Bind(string, interface{})
 		Alias(string, string)
 		Exists(string) bool
 		Singleton(string, func(app ApplicationContainer) interface{})
+		Make(interface{}, ...string) error
+		Fill(interface{}) error
+		Scoped(string, func(app ApplicationContainer) interface{})
+		NewScope() ScopeContainer
+		Tag([]string, ...string)
+		Tagged(string) []interface{}
+		When(string) ContextualBindingBuilder
+		Extend(string, func(interface{}, ApplicationContainer) interface{})
+		// 生命周期钩子
+		Booting(func(ApplicationContainer))
+		Booted(func(ApplicationContainer))
+		Terminating(func(ApplicationContainer))
+		AfterResolving(string, func(interface{}, ApplicationContainer))
+}
+
+// 上下文绑定 When(consumer).Needs(abstract).Give(implementation)
+type ContextualBindingBuilder interface {
+		Needs(string) ContextualBindingNeeds
+}
+
+type ContextualBindingNeeds interface {
+		Give(interface{})
+}
+
+// 作用域容器 (每请求/每任务), 未找到的服务 回退到父容器
+type ScopeContainer interface {
+		ApplicationContainer
+		End()
 }
 
 type ClazzInterface interface {
@@ -65,6 +103,17 @@ type Provider interface {
 		BootInterface
 }
 
+// 服务提供器依赖 (服务提供器名)
+type DependsOnInterface interface {
+		DependsOn() []string
+}
+
+// 延迟加载服务提供器, 首次解析 Provides 中的 id 时 才加载
+type DeferrableProvider interface {
+		Provider
+		Provides() []string
+}
+
 type SupportBean struct {
 		Register bool
 		Boot     bool
@@ -21,4 +21,16 @@ const (
 		AppBasePath         = "BasePath"
 		AppDebug            = "app_debug"
 		AppHealth           = "AppHealth"
+		AppEnvPresets       = "App.Env.Presets"
+		AppPidFile          = "App.PidFile"
+		AppSingleInstance   = "App.SingleInstance"
+		AppDaemon           = "App.Daemon"
+		AppNameKey          = "AppName"
+		AppVersion          = "Version"
+		AppGitCommit        = "GitCommit"
+		AppBuildTime        = "BuildTime"
+		AppGoVersion        = "GoVersion"
+		AppConfigOverrides  = "App.Config.Overrides"
+		AppCommandOptions   = "App.Command.Options"
+		AppVerbose          = "App.Verbose"
 )
@@ -5,9 +5,12 @@ import (
 		"github.com/kataras/iris"
 		"github.com/webGameLinux/kits/Components"
 		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs/Databases"
 		"github.com/webGameLinux/kits/Libs/Schemas"
 		"github.com/webGameLinux/kits/Supports"
+		"os"
 		"reflect"
+		"sync"
 		"time"
 )
 
@@ -22,8 +25,8 @@ func Implements(obj interface{}, face interface{}) bool {
 		return t.Implements(reflect.TypeOf(face).Elem())
 }
 
-// 添加项目自定义引导加载器
-func Bootstrap(apps ...Contracts.ApplicationContainer) {
+// 添加项目自定义引导加载器, 命令行参数错误时 返回 error, 由调用方 决定退出码
+func Bootstrap(apps ...Contracts.ApplicationContainer) error {
 		if len(apps) == 0 {
 				apps = append(apps, AppContainer())
 		}
@@ -34,14 +37,27 @@ func Bootstrap(apps ...Contracts.ApplicationContainer) {
 		InitRegister(app)
 		InitProviders(app)
 		InitBootstrapper(app)
-		InitAppProperties(app)
+		return InitAppProperties(app)
 }
 
-// 初始化 provider
+// 控制台内核, 首个参数为命令名时 执行命令 返回退出码
+func Console(app Contracts.ApplicationContainer) (int, bool) {
+		if kernel, ok := app.(Supports.ConsoleKernel); ok {
+				return kernel.HandleCommand(os.Stdout)
+		}
+		return 0, false
+}
+
+// 初始化 provider, 定时任务 数据库健康检查 由默认清单加载, 配置 app.providers 可 "-" 前缀禁用
 func InitProviders(app Contracts.ApplicationContainer)  {
 		// app.Register(Schemas.IrisHttpServerOf())
 		app.Register(Components.SchemaServiceProviderOf())
 		app.Register(Schemas.BeegoHttpServerOf())
+		if loader, ok := app.(Contracts.PropertyLoaderInterface); ok {
+				loader.PropertyLoader(func(p *sync.Map) {
+						p.LoadOrStore(Supports.ProvidersManifestKey, []string{Components.ScheduleProviderClass, Databases.RedisConnectorProviderClass, Databases.MysqlConnectorProviderClass, Databases.DatabaseHealthProviderClass})
+				})
+		}
 }
 
 // 初始化引导
@@ -56,18 +72,21 @@ func InitBootstrapper(app Contracts.ApplicationContainer)  {
 // 注册相关函数和对象
 func InitRegister(app Contracts.ApplicationContainer)  {
 		app.Bind(Components.ConfigureLoaderName, Components.ViperConfigLoader)
-		app.Bind(Schemas.IrisConfigurationProviderBootPrepares, RegisterOnInterrupt)
 }
 
-// 初始化应用相关 属性配置
-func InitAppProperties(app Contracts.ApplicationContainer) {
+// 初始化应用相关 属性配置, 返回命令行参数错误
+func InitAppProperties(app Contracts.ApplicationContainer) error {
 		if loader, ok := app.(Contracts.PropertyLoaderInterface); ok {
 				props := Supports.AppBasePropertiesOf()
 				if !props.Inited() {
 						props.Init()
 				}
+				if err := props.Err(); err != nil {
+						return err
+				}
 				props.Foreach(props.Configure(loader))
 		}
+		return nil
 }
 
 // 获取 BootstrapProvider
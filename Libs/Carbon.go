@@ -17,3 +17,20 @@ func CarbonOf() *CarbonImpl {
 		obj.Instance = NewCarbon(t)
 		return obj
 }
+
+// 指定时区的当前时间, eg: Asia/Shanghai, 空为本地时区
+func CarbonIn(timezone string) (*CarbonImpl, error) {
+		if timezone == "" {
+				return CarbonOf(), nil
+		}
+		instance, err := NowInLocation(timezone)
+		if err != nil {
+				return nil, err
+		}
+		return &CarbonImpl{Instance: instance}, nil
+}
+
+// 时区
+func (this *CarbonImpl) Location() *time.Location {
+		return this.Instance.Location()
+}
@@ -0,0 +1,20 @@
+package Libs
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"testing"
+		"time"
+)
+
+func TestCarbonIn(t *testing.T) {
+		Convey("Carbon Timezone Test", t, func() {
+				carbon, err := CarbonIn("Asia/Shanghai")
+				So(err, ShouldBeNil)
+				So(carbon.Location().String(), ShouldEqual, "Asia/Shanghai")
+				carbon, err = CarbonIn("")
+				So(err, ShouldBeNil)
+				So(carbon.Location(), ShouldEqual, time.Local)
+				_, err = CarbonIn("Mars/Olympus")
+				So(err, ShouldNotBeNil)
+		})
+}
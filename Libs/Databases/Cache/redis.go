@@ -15,6 +15,7 @@ type RedisProvider interface {
 		Conn(...string) *redis.Conn
 		Context() context.Context
 		Client(...string) *redis.Client
+		Ping(context.Context) error
 }
 
 type ResultInterface interface {
@@ -298,6 +299,11 @@ func (this *RedisInstance) Close() {
 		this.connector = nil
 }
 
+// 连接检查
+func (this *RedisInstance) Ping(ctx context.Context) error {
+		return this.Client().Ping(ctx).Err()
+}
+
 func (this *RedisInstance) Context() context.Context {
 		ctx, cancel := context.WithTimeout(context.Background(), this.getContextTimeout())
 		return context.WithValue(ctx, ContextCancelFunc, cancel)
@@ -0,0 +1,149 @@
+package Databases
+
+import (
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs/Databases/Cache"
+		"github.com/webGameLinux/kits/Libs/Databases/MySql"
+		"os"
+)
+
+// 连接器服务提供器
+// 配置 (或环境变量) 存在时 以连接器 id 注册单例, 供业务使用 并由 DatabaseHealthProvider 注册检查项
+type ConnectorProvider interface {
+		Contracts.Provider
+		Contracts.DependsOnInterface
+}
+
+type connectorProviderImpl struct {
+		Name  string
+		id    string
+		scope string
+		env   string
+		keys  []string
+		// 按配置项 创建连接器
+		connect func(options map[string]string) interface{}
+		clazz   Contracts.ClazzInterface
+		bean    Contracts.SupportInterface
+		app     Contracts.ApplicationContainer
+}
+
+const (
+		RedisConnectorProviderClass = "RedisConnectorProvider"
+		MysqlConnectorProviderClass = "MysqlConnectorProvider"
+		// 配置项前缀, eg: redis.addr=127.0.0.1:6379, mysql.host=127.0.0.1
+		RedisConfigScope = "redis"
+		MysqlConfigScope = "mysql"
+)
+
+// 注册到服务提供器清单
+func init() {
+		Components.RegisterProvider(RedisConnectorProviderClass, func() Contracts.Provider { return NewRedisConnectorProvider() })
+		Components.RegisterProvider(MysqlConnectorProviderClass, func() Contracts.Provider { return NewMysqlConnectorProvider() })
+}
+
+// redis 连接器, 配置 redis.addr redis.username redis.password redis.db 或环境变量 redis_addr
+func NewRedisConnectorProvider() ConnectorProvider {
+		var keys = []string{"addr", "username", "password", "db"}
+		return newConnectorProvider(RedisConnectorProviderClass, RedisConnector, RedisConfigScope, Cache.EnvRedisAddr, keys, func(options map[string]string) interface{} {
+				var args = make(map[string]interface{}, len(options))
+				for key, value := range options {
+						args[key] = value
+				}
+				return Cache.Redis(args)
+		})
+}
+
+// mysql 连接器, 配置 mysql.host mysql.port mysql.user mysql.password mysql.dbname mysql.charset 或环境变量 db_host
+func NewMysqlConnectorProvider() ConnectorProvider {
+		var keys = []string{"host", "port", "user", "password", "dbname", "charset"}
+		return newConnectorProvider(MysqlConnectorProviderClass, MysqlConnector, MysqlConfigScope, MySql.EnvDbHost, keys, func(options map[string]string) interface{} {
+				return MySql.NewMysqlConnector(MySql.NewConnection(options))
+		})
+}
+
+func newConnectorProvider(name string, id string, scope string, env string, keys []string, connect func(map[string]string) interface{}) *connectorProviderImpl {
+		var provider = new(connectorProviderImpl)
+		provider.Name = name
+		provider.id = id
+		provider.scope = scope
+		provider.env = env
+		provider.keys = keys
+		provider.connect = connect
+		return provider
+}
+
+func (this *connectorProviderImpl) Init(app Contracts.ApplicationContainer) {
+		if this.app == nil {
+				this.app = app
+		}
+}
+
+func (this *connectorProviderImpl) GetClazz() Contracts.ClazzInterface {
+		if this.clazz == nil {
+				this.clazz = Components.ClazzOf(this)
+		}
+		return this.clazz
+}
+
+func (this *connectorProviderImpl) GetSupportBean() Contracts.SupportInterface {
+		if this.bean == nil {
+				this.bean = Components.BeanOf()
+		}
+		return this.bean
+}
+
+func (this *connectorProviderImpl) DependsOn() []string {
+		return []string{Components.ConfigureProviderClass}
+}
+
+// 已配置时 注册连接器单例, 首次解析时 按配置创建
+func (this *connectorProviderImpl) Register() {
+		if this.app.Exists(this.id) || !this.configured() {
+				return
+		}
+		this.app.Singleton(this.id, func(app Contracts.ApplicationContainer) interface{} {
+				return this.connect(this.options())
+		})
+}
+
+func (this *connectorProviderImpl) Boot() {
+
+}
+
+func (this *connectorProviderImpl) String() string {
+		return this.Name
+}
+
+func (this *connectorProviderImpl) Constructor() interface{} {
+		return newConnectorProvider(this.Name, this.id, this.scope, this.env, this.keys, this.connect)
+}
+
+func (this *connectorProviderImpl) Factory(app Contracts.ApplicationContainer) interface{} {
+		this.Init(app)
+		return this
+}
+
+// 配置项 scope.keys[0] 或环境变量 存在时 视为已配置
+func (this *connectorProviderImpl) configured() bool {
+		if os.Getenv(this.env) != "" {
+				return true
+		}
+		config, ok := this.app.Get(Components.ConfigureProviderClass).(Components.ConfigureProvider)
+		return ok && config.Get(this.scope+"."+this.keys[0]) != ""
+}
+
+// 配置项, 未配置的项 由连接器 取环境变量 或默认值
+func (this *connectorProviderImpl) options() map[string]string {
+		var options = make(map[string]string)
+		config, ok := this.app.Get(Components.ConfigureProviderClass).(Components.ConfigureProvider)
+		if !ok {
+				return options
+		}
+		for _, key := range this.keys {
+				if value := config.Get(this.scope + "." + key); value != "" {
+						options[key] = value
+				}
+		}
+		return options
+}
@@ -4,6 +4,7 @@ import (
 		"context"
 		"crypto/tls"
 		"encoding/json"
+		"errors"
 		"github.com/coreos/etcd/clientv3"
 		"go.uber.org/zap"
 		"google.golang.org/grpc"
@@ -19,6 +20,7 @@ type Connector interface {
 		Context() context.Context
 		GetConfig() clientv3.Config
 		SetConfig(clientv3.Config) Connector
+		Ping(context.Context) error
 }
 
 type ConnectorImpl struct {
@@ -90,6 +92,21 @@ func (this *ConnectorImpl) Conn() *clientv3.Client {
 		return this.client
 }
 
+// 连接检查, 任一节点可用即成功
+func (this *ConnectorImpl) Ping(ctx context.Context) error {
+		var err = errors.New("etcd connect failed")
+		client := this.Conn()
+		if client == nil {
+				return err
+		}
+		for _, endpoint := range client.Endpoints() {
+				if _, err = client.Status(ctx, endpoint); err == nil {
+						return nil
+				}
+		}
+		return err
+}
+
 func (this *ConnectorImpl) Context() context.Context {
 		ctx, fn := context.WithTimeout(context.Background(), this.getContextTimeout())
 		return context.WithValue(ctx, ContextCancelFunc, fn)
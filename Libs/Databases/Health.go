@@ -0,0 +1,162 @@
+package Databases
+
+import (
+		"context"
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs/Databases/Cache"
+		"github.com/webGameLinux/kits/Libs/Databases/Etcd"
+		"github.com/webGameLinux/kits/Libs/Databases/Message"
+		"github.com/webGameLinux/kits/Libs/Databases/Mongodb"
+		"github.com/webGameLinux/kits/Libs/Databases/MySql"
+		"sync"
+)
+
+// 数据库连接 健康检查 服务提供器
+// 容器中已注册的连接器 (RedisConnector, MysqlConnector ...) 自动注册检查项, 复用其连接
+type DatabaseHealthProvider interface {
+		Contracts.Provider
+		Contracts.DependsOnInterface
+}
+
+type databaseHealthProviderImpl struct {
+		Name  string
+		clazz Contracts.ClazzInterface
+		bean  Contracts.SupportInterface
+		app   Contracts.ApplicationContainer
+}
+
+var (
+		databaseHealthLock     sync.Once
+		databaseHealthInstance *databaseHealthProviderImpl
+)
+
+const (
+		DatabaseHealthProviderClass = "DatabaseHealthProvider"
+		RedisHealthName             = "redis"
+		MysqlHealthName             = "mysql"
+		EtcdHealthName              = "etcd"
+		MongodbHealthName           = "mongodb"
+		NatsHealthName              = "nats"
+		// 容器中的连接器 id, redis mysql 由 RedisConnectorProvider MysqlConnectorProvider 按配置注册
+		// 其余需自行注册, eg: app.Singleton(Databases.EtcdConnector, func(app Contracts.ApplicationContainer) interface{} { return Etcd.NewConnector(options) })
+		RedisConnector   = "RedisConnector"
+		MysqlConnector   = "MysqlConnector"
+		EtcdConnector    = "EtcdConnector"
+		MongodbConnector = "MongodbConnector"
+		NatsConnector    = "NatsConnector"
+)
+
+// 检查项 => 连接器 id
+var databaseConnectors = []struct {
+		name string
+		id   string
+}{
+		{RedisHealthName, RedisConnector},
+		{MysqlHealthName, MysqlConnector},
+		{EtcdHealthName, EtcdConnector},
+		{MongodbHealthName, MongodbConnector},
+		{NatsHealthName, NatsConnector},
+}
+
+func newDatabaseHealthProvider() {
+		databaseHealthInstance = NewDatabaseHealthProvider().(*databaseHealthProviderImpl)
+}
+
+// 创建独立的数据库健康检查服务 (非全局)
+func NewDatabaseHealthProvider() DatabaseHealthProvider {
+		var provider = new(databaseHealthProviderImpl)
+		provider.Name = DatabaseHealthProviderClass
+		return provider
+}
+
+// 注册到服务提供器清单
+func init() {
+		Components.RegisterProvider(DatabaseHealthProviderClass, func() Contracts.Provider { return NewDatabaseHealthProvider() })
+}
+
+func DatabaseHealthProviderOf() DatabaseHealthProvider {
+		if databaseHealthInstance == nil {
+				databaseHealthLock.Do(newDatabaseHealthProvider)
+		}
+		return databaseHealthInstance
+}
+
+func (this *databaseHealthProviderImpl) Init(app Contracts.ApplicationContainer) {
+		if this.app == nil {
+				this.app = app
+		}
+}
+
+func (this *databaseHealthProviderImpl) GetClazz() Contracts.ClazzInterface {
+		if this.clazz == nil {
+				this.clazz = Components.ClazzOf(this)
+		}
+		return this.clazz
+}
+
+func (this *databaseHealthProviderImpl) GetSupportBean() Contracts.SupportInterface {
+		if this.bean == nil {
+				this.bean = Components.BeanOf()
+		}
+		return this.bean
+}
+
+func (this *databaseHealthProviderImpl) DependsOn() []string {
+		return []string{Components.HealthRegistryProviderClass}
+}
+
+func (this *databaseHealthProviderImpl) Register() {
+		if !this.app.Exists(this.String()) {
+				this.app.Bind(this.String(), this)
+		}
+}
+
+// 全部服务提供器引导后 为已注册的连接器 添加检查项
+func (this *databaseHealthProviderImpl) Boot() {
+		registry, ok := this.app.Get(Components.HealthRegistryProviderClass).(Components.HealthRegistry)
+		if !ok {
+				return
+		}
+		this.app.Booted(func(app Contracts.ApplicationContainer) {
+				for _, it := range databaseConnectors {
+						if app.Exists(it.id) {
+								registry.Add(it.name, this.checker(it.id))
+						}
+				}
+		})
+}
+
+func (this *databaseHealthProviderImpl) String() string {
+		return this.Name
+}
+
+func (this *databaseHealthProviderImpl) Constructor() interface{} {
+		return NewDatabaseHealthProvider()
+}
+
+func (this *databaseHealthProviderImpl) Factory(app Contracts.ApplicationContainer) interface{} {
+		this.Init(app)
+		return this
+}
+
+// 解析容器中的连接器 检查连接
+func (this *databaseHealthProviderImpl) checker(id string) Components.HealthChecker {
+		return func(ctx context.Context) (map[string]interface{}, error) {
+				var details = map[string]interface{}{"connector": id}
+				switch conn := this.app.Get(id).(type) {
+				case Cache.RedisProvider:
+						return details, conn.Ping(ctx)
+				case MySql.GormConnector:
+						return details, conn.Ping(ctx)
+				case Etcd.Connector:
+						return details, conn.Ping(ctx)
+				case Mongodb.Connector:
+						return details, conn.Ping()
+				case Message.Connector:
+						return details, conn.Ping(ctx)
+				}
+				return details, fmt.Errorf("%s is not a database connector", id)
+		}
+}
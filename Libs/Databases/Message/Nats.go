@@ -1,12 +1,15 @@
 package Message
 
 import (
+		"context"
+		"errors"
 		"github.com/nats-io/nats.go"
 		"os"
 )
 
 type Connector interface {
 		Conn() *nats.Conn
+		Ping(context.Context) error
 }
 
 type NatsConnector struct {
@@ -75,3 +78,12 @@ func (this *NatsConnector) Close() {
 		this.connector.Close()
 		this.connector = nil
 }
+
+// 连接检查
+func (this *NatsConnector) Ping(ctx context.Context) error {
+		conn := this.Conn()
+		if conn == nil {
+				return errors.New("nats connect failed")
+		}
+		return conn.FlushWithContext(ctx)
+}
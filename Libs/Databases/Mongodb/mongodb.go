@@ -1,10 +1,14 @@
 package Mongodb
 
-import "gopkg.in/mgo.v2"
+import (
+		"errors"
+		"gopkg.in/mgo.v2"
+)
 
 type Connector interface {
 		Conn() *mgo.Session
 		Close()
+		Ping() error
 }
 
 type Mongodb struct {
@@ -60,3 +64,12 @@ func (this *Mongodb) Close() {
 		this.connector.Close()
 		this.connector = nil
 }
+
+// 连接检查
+func (this *Mongodb) Ping() error {
+		session := this.Conn()
+		if session == nil {
+				return errors.New("mongodb connect failed")
+		}
+		return session.Ping()
+}
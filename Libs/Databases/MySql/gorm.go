@@ -1,7 +1,9 @@
 package MySql
 
 import (
+		"context"
 		"encoding/json"
+		"errors"
 		"fmt"
 		"github.com/jinzhu/gorm"
 		_ "github.com/jinzhu/gorm/dialects/mysql"
@@ -18,6 +20,7 @@ type OrmMysqlConnector struct {
 type GormConnector interface {
 		Close()
 		Conn(...string) *gorm.DB
+		Ping(context.Context) error
 }
 
 const (
@@ -325,3 +328,12 @@ func (this *OrmMysqlConnector) Conn(db ...string) *gorm.DB {
 		}
 		return this.connector
 }
+
+// 连接检查
+func (this *OrmMysqlConnector) Ping(ctx context.Context) error {
+		conn := this.Conn()
+		if conn == nil {
+				return errors.New("mysql connect failed")
+		}
+		return conn.DB().PingContext(ctx)
+}
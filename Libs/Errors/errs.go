@@ -7,6 +7,10 @@ const (
 		nilClientCode  = 20002
 		typeError      = 20003
 		unmarshalError = 20004
+		unresolvable   = 20005
+		dependency     = 20006
+		resolution     = 20007
+		circular       = 20008
 )
 
 type Error struct {
@@ -38,6 +42,28 @@ func UnmarshalError(message string) *Error {
 		return _error(unmarshalError, "unmarshal failed error", message)
 }
 
+func UnresolvableError(message string) *Error {
+		return _error(unresolvable, "unresolvable dependency error", message)
+}
+
+func DependencyError(message string) *Error {
+		return _error(dependency, "provider dependency error", message)
+}
+
+func ResolutionError(message string) *Error {
+		return _error(resolution, "service resolution error", message)
+}
+
+func CircularError(message string) *Error {
+		return _error(circular, "circular dependency error", message)
+}
+
+// 是否循环依赖错误
+func IsCircularError(err error) bool {
+		e, ok := err.(*Error)
+		return ok && e != nil && e.Code == circular
+}
+
 func (e *Error) Error() string {
 		return fmt.Sprintf("%d - %s", e.Code, e.Message)
 }
@@ -169,7 +169,7 @@ func (this *LoggerBird) AppendChannel(mapper map[string][]chan interface{}) {
 
 func (this *LoggerBird) Send(key string, args ...interface{}) {
 		this.Notify(key, args)
-		if this.Logger != nil {
+		if this.Logger == nil {
 				args = append(args, key)
 				sysLog.Println(args...)
 				return
@@ -1,11 +1,13 @@
 package Schemas
 
 import (
+		"context"
 		"github.com/astaxie/beego"
 		"github.com/webGameLinux/kits/Components"
 		"github.com/webGameLinux/kits/Contracts"
 		"github.com/webGameLinux/kits/Supports"
 		"sync"
+		"time"
 )
 
 type BeegoHttpServerProvider interface {
@@ -39,8 +41,10 @@ const (
 		BeegoHttpServerClass = "BeegoHttpServer"
 		BeegoRegisterBefore  = "BeegoRegisterBefore"
 		BeegoBootBefore      = "BeegoBootBefore"
+		BeegoBootTag         = "beego.boot"
 		BootBeforeFnName     = "BootBeforeFnName"
 		RegisterBeforeFnName = "RegisterBeforeFnName"
+		BeegoShutdownTimeout = 5 * time.Second
 )
 
 func newBeegoHttpServer() {
@@ -58,6 +62,24 @@ func BeegoHttpServerOf(args ...interface{}) BeegoHttpServerProvider {
 		return beegoInstance
 }
 
+// 创建独立的 beego 服务 (非全局), 未指定 *beego.App 时 使用新的 beego.App
+func NewBeegoHttpServer(args ...interface{}) BeegoHttpServerProvider {
+		var provider = new(beegoHttpServerImpl)
+		provider.boots = []BootBeforeFn{}
+		provider.registers = []RegisterBeforeFn{}
+		provider.init(args...)
+		if provider.server == nil {
+				provider.server = beego.NewApp()
+		}
+		provider.defaults()
+		return provider
+}
+
+// 注册到服务提供器清单
+func init() {
+		Components.RegisterProvider(BeegoHttpServerClass, func() Contracts.Provider { return NewBeegoHttpServer() })
+}
+
 func (this *beegoHttpServerImpl) defaults() {
 		this.Name = BeegoHttpServerClass
 		if this.server == nil {
@@ -139,7 +161,26 @@ func (this *beegoHttpServerImpl) Boot() {
 				return
 		}
 		this.boot()
-		go this.Server().Run()
+		if Components.RunningCommand(this.app) {
+				return
+		}
+		this.running = true
+		// 每个请求 创建作用域, 通过 Components.ScopeOf(ctx.Request.Context()) 获取
+		go this.Server().Run(Components.ScopeMiddleware(this.app))
+}
+
+// 优雅关闭, 等待处理中的请求
+func (this *beegoHttpServerImpl) Destroy() {
+		var server = this.Server()
+		if !this.running || server == nil || server.Server == nil {
+				return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), BeegoShutdownTimeout)
+		defer cancel()
+		if err := server.Server.Shutdown(ctx); err != nil {
+				beego.Error(err)
+		}
+		this.running = false
 }
 
 func (this *beegoHttpServerImpl) App() Contracts.ApplicationContainer {
@@ -181,9 +222,18 @@ func (this *beegoHttpServerImpl) register() {
 
 func (this *beegoHttpServerImpl) boot() {
 		before := this.app.Get(BeegoBootBefore)
+		this.health()
 		for _, fn := range this.boots {
 				fn(this)
 		}
+		for _, it := range this.app.Tagged(BeegoBootTag) {
+				if fn, ok := it.(BootBeforeFn); ok {
+						fn(this)
+				}
+				if fn, ok := it.(func(BeegoHttpServerProvider)); ok {
+						fn(this)
+				}
+		}
 		if before == nil {
 				return
 		}
@@ -209,6 +259,17 @@ func (this *beegoHttpServerImpl) boot() {
 		}
 }
 
+// 注册 /healthz (存活), /readyz (就绪) 和 /version (构建信息)
+func (this *beegoHttpServerImpl) health() {
+		registry, ok := this.app.Get(Components.HealthRegistryProviderClass).(Components.HealthRegistry)
+		if !ok {
+				return
+		}
+		this.Server().Handlers.Handler(Components.HealthLivenessPath, registry.LivenessHandler())
+		this.Server().Handlers.Handler(Components.HealthReadinessPath, registry.ReadinessHandler())
+		this.Server().Handlers.Handler(Components.VersionPath, Components.VersionHandler(this.app))
+}
+
 func (this *beegoHttpServerImpl) String() string {
 		return this.Name
 }
@@ -228,7 +289,7 @@ func (this *beegoHttpServerImpl) Server() *beego.App {
 }
 
 func (this *beegoHttpServerImpl) Constructor() interface{} {
-		return BeegoHttpServerOf()
+		return NewBeegoHttpServer()
 }
 
 func (this *beegoHttpServerImpl) Factory(app Contracts.ApplicationContainer) interface{} {
@@ -1,13 +1,17 @@
 package Schemas
 
 import (
+		stdContext "context"
 		"fmt"
 		"github.com/kataras/iris"
 		"github.com/kataras/iris/core/host"
 		"github.com/webGameLinux/kits/Components"
 		"github.com/webGameLinux/kits/Contracts"
+		"net/http"
 		"strings"
 		"sync"
+		"sync/atomic"
+		"time"
 )
 
 type irisHttpServer struct {
@@ -17,6 +21,8 @@ type irisHttpServer struct {
 		app        Contracts.ApplicationContainer
 		clazz      Contracts.ClazzInterface
 		runner     iris.Runner
+		// 运行状态, 1: 运行中 0: 未运行
+		state int32
 }
 
 type PreparesFunc func(app *iris.Application)
@@ -44,19 +50,32 @@ var (
 const (
 		IrisHttpServerClass                   = "IrisHttpServer"
 		IrisApplication                       = "IrisApplication"
-		IrisAppState                          = "IrisInstanceState"
+		IrisAppState                          = "IrisInstanceState" // Deprecated: 运行状态 由实例维护, 不再绑定到容器
 		IrisConfigurationProvider             = "IrisConfigurationProvider"
 		IrisRegisterAfters                    = "IrisRegisterAfters"
 		IrisRunnerHostConfigurators           = "IrisRunnerHostConfigurators"
 		IrisConfigPrefixKey                   = "http.iris"
 		IrisRunner                            = "IrisRunner"
 		IrisConfigurationProviderBootPrepares = "IrisConfigurationProviderBootPrepares"
+		IrisPrepareTag                        = "iris.prepare"
+		IrisShutdownTimeout                   = 5 * time.Second
 )
 
 func irisHttpServerNew() {
-		irisApp = new(irisHttpServer)
-		irisApp.irisServer = iris.New()
-		irisApp.Name = IrisHttpServerClass
+		irisApp = NewIrisHttpServer()
+}
+
+// 创建独立的 iris 服务 (非全局)
+func NewIrisHttpServer() *irisHttpServer {
+		var provider = new(irisHttpServer)
+		provider.irisServer = iris.New()
+		provider.Name = IrisHttpServerClass
+		return provider
+}
+
+// 注册到服务提供器清单
+func init() {
+		Components.RegisterProvider(IrisHttpServerClass, func() Contracts.Provider { return NewIrisHttpServer() })
 }
 
 func IrisHttpServerOf() *irisHttpServer {
@@ -165,12 +184,23 @@ func (this *irisHttpServer) getConfigureProvider() Components.ConfigureProvider
 		if provider, ok := config.(Components.ConfigureProvider); ok {
 				return provider
 		}
-		return Components.ConfigureProviderOf()
+		var provider = Components.NewConfigureProvider()
+		provider.Init(this.app)
+		return provider
 }
 
 // boot 引导启动
 func (this *irisHttpServer) Boot() {
 		this.prepare()
+		if Components.RunningCommand(this.app) {
+				return
+		}
+		// 由 Supervisor 监管, 重启策略 schema.IrisHttpServer.restart
+		if supervisor, ok := this.app.Get(Components.SupervisorClass).(Components.Supervisor); ok {
+				supervisor.Supervise(this)
+				supervisor.Start()
+				return
+		}
 		this.StartUp()
 }
 
@@ -178,6 +208,19 @@ func (this *irisHttpServer) Boot() {
 func (this *irisHttpServer) prepare() {
 		// 注入配置
 		this.Server().Configure(this.getIrisConfigure())
+		// 健康检查
+		this.health()
+		// 请求作用域
+		this.scope()
+		// 标签前置
+		for _, it := range this.app.Tagged(IrisPrepareTag) {
+				if fn, ok := it.(PreparesFunc); ok {
+						fn(this.GetServer())
+				}
+				if fn, ok := it.(func(*iris.Application)); ok {
+						fn(this.GetServer())
+				}
+		}
 		// 获取前置 逻辑
 		bootPrepares := this.app.Get(IrisConfigurationProviderBootPrepares)
 		if bootPrepares == nil {
@@ -196,23 +239,62 @@ func (this *irisHttpServer) prepare() {
 		}
 }
 
+// 注册 /healthz (存活), /readyz (就绪) 和 /version (构建信息)
+func (this *irisHttpServer) health() {
+		registry, ok := this.app.Get(Components.HealthRegistryProviderClass).(Components.HealthRegistry)
+		if !ok {
+				return
+		}
+		this.Server().Get(Components.HealthLivenessPath, iris.FromStd(registry.LivenessHandler()))
+		this.Server().Get(Components.HealthReadinessPath, iris.FromStd(registry.ReadinessHandler()))
+		this.Server().Get(Components.VersionPath, iris.FromStd(Components.VersionHandler(this.app)))
+}
+
+// 每个请求 创建作用域, 通过 Components.ScopeOf(ctx.Request().Context()) 获取
+func (this *irisHttpServer) scope() {
+		middleware := Components.ScopeMiddleware(this.app)
+		this.Server().WrapRouter(func(writer http.ResponseWriter, request *http.Request, router http.HandlerFunc) {
+				middleware(router).ServeHTTP(writer, request)
+		})
+}
+
 func (this *irisHttpServer) StartUp() {
 		if this.started() {
 				return
 		}
-		go this.run()
 		this.start()
+		go this.run()
 }
 
 // 启动服务
 func (this *irisHttpServer) run() {
 		err := this.Server().Run(this.getServerRunner())
+		this.stop()
 		this.logger(err)
 		if err != nil {
 				this.app.Stop()
 		}
 }
 
+// 监管运行, 阻塞到服务退出; ctx 结束时 优雅关闭
+func (this *irisHttpServer) Serve(ctx stdContext.Context) error {
+		var done = make(chan error, 1)
+		this.start()
+		go func() {
+				done <- this.Server().Run(this.getServerRunner(), iris.WithoutServerError(iris.ErrServerClosed))
+		}()
+		var err error
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+				this.Destroy()
+				err = <-done
+		}
+		this.stop()
+		this.logger(err)
+		return err
+}
+
 // 服务停止日志记录
 func (this *irisHttpServer) logger(stringer interface{}) {
 		if stringer == nil {
@@ -279,26 +361,26 @@ func (this *irisHttpServer) GetHttpAddr() string {
 		return fmt.Sprintf("%s:%s", _host, _port)
 }
 
-func (this *irisHttpServer) started() bool {
-		state := this.app.Get(IrisAppState)
-		if state == nil {
-				return false
-		}
-		if n, ok := state.(int); ok {
-				return n > 0
-		}
-		if n, ok := state.(bool); ok {
-				return n
+// 优雅关闭, 等待处理中的请求
+func (this *irisHttpServer) Destroy() {
+		if !this.started() {
+				return
 		}
-		return false
+		ctx, cancel := stdContext.WithTimeout(stdContext.Background(), IrisShutdownTimeout)
+		defer cancel()
+		this.logger(this.Server().Shutdown(ctx))
+}
+
+func (this *irisHttpServer) started() bool {
+		return atomic.LoadInt32(&this.state) > 0
 }
 
 func (this *irisHttpServer) start() {
-		this.app.Bind(IrisAppState, 1)
+		atomic.StoreInt32(&this.state, 1)
 }
 
 func (this *irisHttpServer) stop() {
-		this.app.Bind(IrisAppState, -1)
+		atomic.StoreInt32(&this.state, 0)
 }
 
 func (this *irisHttpServer) GetSupportBean() Contracts.SupportInterface {
@@ -308,13 +390,18 @@ func (this *irisHttpServer) GetSupportBean() Contracts.SupportInterface {
 		return this.bean
 }
 
+// iris 依赖配置服务
+func (this *irisHttpServer) DependsOn() []string {
+		return []string{Components.ConfigureProviderClass}
+}
+
 func (this *irisHttpServer) Factory(container Contracts.ApplicationContainer) interface{} {
 		this.Init(container)
 		return this
 }
 
 func (this *irisHttpServer) Constructor() interface{} {
-		return IrisHttpServerOf()
+		return NewIrisHttpServer()
 }
 
 func (this *irisHttpServer) GetServer() *iris.Application {
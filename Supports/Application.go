@@ -4,20 +4,19 @@ import (
 		"fmt"
 		"github.com/webGameLinux/kits/Components"
 		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs/Errors"
+		"os"
 		"reflect"
 		"sync"
 		"time"
 )
 
 const (
-		iocLock                = "ioc"
 		bootName               = "boot"
 		AppContainer           = "app"
 		appSingletonLock       = "appSingleton"
 		providerName           = "provider"
 		registerName           = "register"
-		propertiesLock         = "properties"
-		providerLock           = "provider"
 		registersPropKey       = "registers"
 		bootsPropKey           = "boots"
 		defaultPropsLock       = "defaultProps"
@@ -29,16 +28,18 @@ const (
 		coreProviderNum = "coreProviderNum"
 		ctrlChan        = "appCtrlChan"
 		BasePath        = "BasePath"
-		StartEv         = "started"
-		StopEv          = "stoped"
+		// 应用启动 停止事件, 可用通配 app.* 监听
+		AppStartedEv = "app.started"
+		AppStoppedEv = "app.stopped"
+		// Deprecated: 使用 AppStartedEv, 兼容旧监听 仍同时分发
+		StartEv = "started"
+		// Deprecated: 使用 AppStoppedEv, 兼容旧监听 仍同时分发
+		StopEv = "stoped"
 )
 
 var (
 		instanceMutex    = sync.Mutex{}
 		appInstanceLocks = map[string]*sync.Once{
-				iocLock:          getInstanceLock(),
-				propertiesLock:   getInstanceLock(),
-				providerLock:     getInstanceLock(),
 				defaultPropsLock: getInstanceLock(),
 				appSingletonLock: getInstanceLock(),
 		}
@@ -47,9 +48,39 @@ var (
 
 type ApplicationImpl struct {
 		properties *sync.Map
+		props      *ApplicationProps
 		container  ContainerApp
 		registers  RegisterUniqueArray
 		boots      BooterUniqueArray
+		providers  map[string]Contracts.Provider
+		// 服务提供器 注册顺序及耗时
+		providerNames []string
+		timings       map[string]*providerTiming
+		timingMutex   sync.Mutex
+		// 初始化 (每个应用独立)
+		iocOnce      sync.Once
+		propsOnce    sync.Once
+		providerOnce sync.Once
+		// 延迟加载
+		deferred      map[string]*deferredProvider
+		deferredMutex sync.Mutex
+		// 销毁
+		booted     []teardownItem
+		resolved   []teardownItem
+		stateMutex sync.Mutex
+		stopOnce   sync.Once
+		// 上下文绑定
+		contexts     map[string]map[string]interface{}
+		contextMutex sync.RWMutex
+		// 扩展 (装饰)
+		extenders   map[string][]Extender
+		extendMutex sync.RWMutex
+		// 解析记录 (依赖图)
+		resolutions *resolutionGraph
+		// 生命周期钩子
+		hooks *lifecycleHooks
+		// 单实例锁
+		instance *instanceLock
 }
 
 // 获取并发单例锁
@@ -83,10 +114,9 @@ func App() Contracts.ApplicationContainer {
 		return appSingleton
 }
 
-// app 工厂
+// app 工厂, 全局应用 使用全局服务提供器
 func appFactory() {
-		appSingleton = NewApp()
-		appSingleton.InitFn()
+		appSingleton = NewApp(WithProps(getApplicationDefaultProps()))
 }
 
 // 空数据构造
@@ -99,7 +129,7 @@ func InstanceOfConstructor(v interface{}) func() interface{} {
 				if ok && clazz != nil {
 						v = clazz
 				} else {
-						v = en.value
+						v = en.Value()
 				}
 		}
 		if fn, ok := v.(func() interface{}); ok {
@@ -124,7 +154,7 @@ func InstanceOfFactory(v interface{}) func(app Contracts.ApplicationContainer) i
 				if ok && clazz != nil {
 						v = clazz
 				} else {
-						v = en.value
+						v = en.Value()
 				}
 		}
 		if fn, ok := v.(func(Contracts.ApplicationContainer) interface{}); ok {
@@ -139,11 +169,34 @@ func InstanceOfFactory(v interface{}) func(app Contracts.ApplicationContainer) i
 		return nil
 }
 
-// 创建App
-func NewApp() *ApplicationImpl {
+// 创建独立的App, 拥有独立的容器,属性和核心服务提供器
+// eg: NewApp(WithBasePath(dir), WithRunMode("test"), WithEnv(map[string]string{"redis_addr": addr}))
+func NewApp(opts ...AppOption) *ApplicationImpl {
+		var app = newApplication()
+		app.props = newApplicationProps()
+		app.IocInit()
+		app.PropsInit()
+		WithCommandOptions(globalOption)(app)
+		for _, opt := range opts {
+				if opt != nil {
+						opt(app)
+				}
+		}
+		app.InitFn()
+		return app
+}
+
+func newApplication() *ApplicationImpl {
 		var app = new(ApplicationImpl)
 		app.boots = BooterUniqueArrayOf()
 		app.registers = RegisterUniqueArrayOf()
+		app.providers = make(map[string]Contracts.Provider)
+		app.deferred = make(map[string]*deferredProvider)
+		app.contexts = make(map[string]map[string]interface{})
+		app.extenders = make(map[string][]Extender)
+		app.resolutions = newResolutionGraph()
+		app.hooks = newLifecycleHooks()
+		app.timings = make(map[string]*providerTiming)
 		return app
 }
 
@@ -185,17 +238,17 @@ func (this *ApplicationImpl) setInit(key string, state ...bool) *ApplicationImpl
 
 // ioc 容器初始化
 func (this *ApplicationImpl) IocInit() {
-		getSafeLock(iocLock).Do(this.iocInitFactory)
+		this.iocOnce.Do(this.iocInitFactory)
 }
 
 // app 相关属性初始化
 func (this *ApplicationImpl) PropsInit() {
-		getSafeLock(propertiesLock).Do(this.propertiesInitFactory)
+		this.propsOnce.Do(this.propertiesInitFactory)
 }
 
 // 初始化核心 服务器提供器
 func (this *ApplicationImpl) InitCoreProviders() {
-		getSafeLock(providerLock).Do(this.initDefaultProviders)
+		this.providerOnce.Do(this.initDefaultProviders)
 }
 
 // 注册默认服务
@@ -226,12 +279,18 @@ func (this *ApplicationImpl) getInitCount(key string) int {
 		return v.(int)
 }
 
-// 初始化相关注册器
-func (this *ApplicationImpl) InitRegisters() {
+// 初始化相关注册器, 依赖未注册 或循环依赖时 返回错误
+func (this *ApplicationImpl) InitRegisters() error {
 		if this.getInitCount(coreRegistersInitCount) > 0 {
-				return
+				return nil
 		}
-		this.registers.Foreach(this.foreachRegister())
+		if err := this.sortRegisters(); err != nil {
+				return err
+		}
+		this.registerCore()
+		// 核心服务注册后 按配置清单 加载/禁用 自定义服务, 并在核心服务引导前 注册
+		this.loadManifest()
+		return this.registerCustom()
 }
 
 // 别名
@@ -239,23 +298,29 @@ func (this *ApplicationImpl) Alias(clazz string, alias string) {
 		this.container.Alias(clazz, alias)
 }
 
-// 初始化相关引导器
-func (this *ApplicationImpl) InitBoots() {
+// 初始化相关引导器, 依赖未注册 或循环依赖时 返回错误
+func (this *ApplicationImpl) InitBoots() error {
 		if this.getInitCount(coreBootInitCount) > 0 {
-				return
+				return nil
 		}
-		this.boots.Foreach(this.foreachBoot())
+		this.fire(bootingHook)
+		if err := this.sortBoots(); err != nil {
+				return err
+		}
+		this.bootCore()
+		return nil
 }
 
 // 载入引导逻辑
 func (this *ApplicationImpl) boot(impl Contracts.BootInterface) {
-		impl.Boot()
+		this.timing(impl, bootName, impl.Boot)
+		this.addBooted(impl)
 		this.properties.Store(coreBootInitCount, this.getInitCount(coreBootInitCount)+1)
 }
 
 // 载入注册逻辑
 func (this *ApplicationImpl) reg(impl Contracts.RegisterInterface) {
-		impl.Register()
+		this.timing(impl, registerName, impl.Register)
 		this.properties.Store(coreRegistersInitCount, this.getInitCount(coreRegistersInitCount)+1)
 }
 
@@ -287,44 +352,123 @@ func (this *ApplicationImpl) property(key string, defaultValue ...interface{}) i
 		return defaultValue[0]
 }
 
-// 获取相关服务或者状态
+// 获取相关服务或者状态, 解析失败时 返回 nil
 func (this *ApplicationImpl) Get(faced string) interface{} {
+		obj, _ := this.Resolve(faced)
+		return obj
+}
+
+// 解析服务
+// 单例 并发时只构造一次, 工厂 panic 或者 返回 error 时 解析失败, 错误被缓存
+func (this *ApplicationImpl) Resolve(faced string) (interface{}, error) {
+		return this.resolveIn(faced, nil)
+}
+
+// 按解析栈解析, 工厂内 经传入的容器 再次解析栈中的服务 返回循环依赖错误
+// 工厂经 外部 app 引用解析时 不携带解析栈, 此类循环依赖 不受支持
+func (this *ApplicationImpl) resolveIn(faced string, stack []string) (interface{}, error) {
 		entry := this.container.Resolver(faced)
 		if entry == nil {
-				return nil
+				this.loadDeferred(faced)
+				entry = this.container.Resolver(faced)
+		}
+		if entry == nil {
+				return nil, Errors.UnresolvableError(faced + " not found")
 		}
-		if !entry.Extras().Bool(SINGLETON) {
-				class, ok := entry.Extras().Load(REAL_CLAZZ)
-				if ok && class != nil {
-						return class
+		// 已构造的单例 直接返回, 不记录解析栈
+		if len(stack) == 0 {
+				if obj, ok := entry.Extras().Load(SINGLETON_OBJECT); ok {
+						return obj, nil
 				}
-				return entry.value
 		}
-		if obj, ok := entry.Extras().Load(SINGLETON_OBJECT); ok {
-				return obj
+		// 作用域服务 只能在作用域内解析
+		if entry.Extras().Bool(SCOPED) {
+				return nil, Errors.ResolutionError(faced + " is scoped, resolve it in a scope")
+		}
+		var kind = BIND
+		if entry.Extras().Bool(SINGLETON) {
+				kind = SINGLETON
+		}
+		if len(stack) > 0 {
+				this.trace(stack, entry.key, kind)
+		}
+		if err := circular(stack, entry.key); err != nil {
+				return nil, err
 		}
-		constructor := InstanceOfConstructor(entry.value)
-		if constructor != nil {
-				instance := constructor()
-				if instance != nil {
-						entry.Extras().Store(SINGLETON_OBJECT, instance)
+		if kind == BIND {
+				return entry.Value(), nil
+		}
+		obj, err := entry.Singleton(func() (interface{}, error) {
+				var container = resolving(this, stack, entry.key)
+				this.trace(nil, entry.key, kind)
+				instance, err := build(faced, func() interface{} {
+						if constructor := InstanceOfConstructor(entry.Value()); constructor != nil {
+								return constructor()
+						}
+						if factory := InstanceOfFactory(entry.Value()); factory != nil {
+								return factory(withContext(container, faced))
+						}
+						return nil
+				})
+				if err == nil {
+						err = container.failed()
+				}
+				if err != nil || instance == nil {
+						return nil, err
 				}
-				return instance
+				instance = this.extend(entry.key, instance, container)
+				this.addResolved(faced, instance)
+				autowire(container, faced, instance)
+				this.afterResolved(entry.key, instance, container)
+				return instance, nil
+		})
+		return obj, err
+}
+
+// 按 inject tag 自动注入对象字段
+// inject:"LoggerProvider" 按 id 注入, inject:"" 按类型注入
+func (this *ApplicationImpl) Fill(obj interface{}) error {
+		return fill(this, obj)
+}
+
+// 解析服务到目标
+// target 指针, struct 时等同 Fill
+// ids    指定服务 id, 为空时按类型解析
+func (this *ApplicationImpl) Make(target interface{}, ids ...string) error {
+		return makeTarget(this, target, ids...)
+}
+
+// 注入解析器
+// id 为空时 按注册顺序 查找第一个类型匹配的 绑定或者已实例化的单例
+func (this *ApplicationImpl) resolveFor(id string, typ reflect.Type) (interface{}, bool) {
+		if id != "" {
+				obj := this.Get(id)
+				return obj, obj != nil
 		}
-		factory := InstanceOfFactory(entry.value)
-		if factory != nil {
-				instance := factory(this)
-				if instance != nil {
-						entry.Extras().Store(SINGLETON_OBJECT, instance)
+		var injector = Components.NewInjector(Components.InjectTag)
+		for _, key := range this.container.Keys() {
+				var (
+						obj   interface{}
+						entry = this.container.Resolver(key)
+				)
+				if entry == nil || entry.Extras().Bool(SCOPED) {
+						continue
+				}
+				if entry.Extras().Bool(SINGLETON) {
+						obj, _ = entry.Extras().Load(SINGLETON_OBJECT)
+				} else {
+						obj = this.Get(key)
+				}
+				if injector.Assignable(obj, typ) {
+						return obj, true
 				}
-				return instance
 		}
-		return nil
+		return nil, false
 }
 
 // 获取相关服务或者状态
 func (this *ApplicationImpl) Exists(faced string) bool {
-		return this.container.Exists(faced)
+		return this.container.Exists(faced) || this.isDeferred(faced)
 }
 
 // 注册服务提供器
@@ -366,6 +510,16 @@ func (this *ApplicationImpl) register(provider Contracts.Provider) {
 		if provider == nil {
 				return
 		}
+		var name = ProviderName(provider)
+		if _, ok := this.providers[name]; !ok {
+				this.providerNames = append(this.providerNames, name)
+		}
+		this.providers[name] = provider
+		// 延迟加载
+		if deferrable, ok := provider.(Contracts.DeferrableProvider); ok && len(deferrable.Provides()) > 0 {
+				this.deferRegister(deferrable)
+				return
+		}
 		// 注入 app
 		provider.Init(this)
 		// 提供相关注册选择
@@ -383,10 +537,14 @@ func (this *ApplicationImpl) Bind(id string, object interface{}) {
 		if object == nil || id == "" {
 				return
 		}
-		this.container.Bind(id, object)
+		if this.container.Exists(id) {
+				return
+		}
+		this.container.Bind(id, this.extend(id, object, this))
 }
 
 // 单例注册
+// 工厂 应经参数 app 解析依赖, 以检测循环依赖; 经闭包中的外部 app 构成的循环 不受支持, 会阻塞
 func (this *ApplicationImpl) Singleton(id string, factory func(Contracts.ApplicationContainer) interface{}) {
 		if id == "" {
 				return
@@ -410,13 +568,17 @@ func (this *ApplicationImpl) iocInitFactory() {
 func (this *ApplicationImpl) propertiesInitFactory() {
 		var mapper = sync.Map{}
 		this.properties = &mapper
-		props := getApplicationDefaultProps()
-		this.properties.Store(defaultPropsKey, props)
+		if this.props == nil {
+				this.props = getApplicationDefaultProps()
+		}
+		this.properties.Store(defaultPropsKey, this.props)
 }
 
-// 发送事件
+// 发送事件 (同步)
 func (this *ApplicationImpl) Emit(event string, target interface{}) {
-		// @todo
+		if dispatcher, ok := this.Get(Components.EventBusProviderClass).(Components.EventDispatcher); ok {
+				dispatcher.Dispatch(event, target)
+		}
 		if Components.Debug() {
 				fmt.Println(event, target)
 		}
@@ -433,25 +595,99 @@ func (this *ApplicationImpl) getBootPointer() int {
 }
 
 // 初始服务提供
-func (this *ApplicationImpl) providersInit() {
-		this.LoadCoreProviders()
-		this.LoadCustomProviders()
+func (this *ApplicationImpl) providersInit() error {
+		if err := this.LoadCoreProviders(); err != nil {
+				return err
+		}
+		if err := this.LoadCustomProviders(); err != nil {
+				return err
+		}
+		this.fire(bootedHook)
+		return nil
 }
 
 // 加载核心服务
-func (this *ApplicationImpl) LoadCoreProviders() {
+func (this *ApplicationImpl) LoadCoreProviders() error {
 		if !this.isInit(registerName) {
-				this.InitRegisters()
+				if err := this.InitRegisters(); err != nil {
+						return err
+				}
+				this.registerCommands()
+				this.setInit(registerName)
 		}
 		if !this.isInit(bootName) {
-				this.InitBoots()
+				if err := this.InitBoots(); err != nil {
+						return err
+				}
+				this.setInit(bootName)
 		}
+		return nil
 }
 
 // 加载自定义 服务器提供器
-func (this *ApplicationImpl) LoadCustomProviders() {
+func (this *ApplicationImpl) LoadCustomProviders() error {
+		if err := this.sortRegisters(); err != nil {
+				return err
+		}
 		this.registers.Foreach(this.foreachRegister())
+		if err := this.sortBoots(); err != nil {
+				return err
+		}
 		this.boots.Foreach(this.foreachBoot())
+		return nil
+}
+
+// 服务提供器 加载失败: 记录日志 并停止应用, 返回非零退出码
+func (this *ApplicationImpl) fail(err error) int {
+		this.logger().Error(err.Error())
+		this.Stop()
+		return Components.ExitFailure
+}
+
+// 按依赖排序 注册器
+func (this *ApplicationImpl) sortRegisters() error {
+		var items []interface{}
+		this.registers.Foreach(func(key, value interface{}) bool {
+				items = append(items, value)
+				return true
+		})
+		sorted, err := SortByDependency(items, this.isKnownDependency)
+		if err != nil {
+				return err
+		}
+		var registers []Contracts.RegisterInterface
+		for _, it := range sorted {
+				registers = append(registers, it.(Contracts.RegisterInterface))
+		}
+		this.registers = RegisterUniqueArrayOf(registers...)
+		return nil
+}
+
+// 按依赖排序 引导器
+func (this *ApplicationImpl) sortBoots() error {
+		var items []interface{}
+		this.boots.Foreach(func(key, value interface{}) bool {
+				items = append(items, value)
+				return true
+		})
+		sorted, err := SortByDependency(items, this.isKnownDependency)
+		if err != nil {
+				return err
+		}
+		var boots []Contracts.BootInterface
+		for _, it := range sorted {
+				boots = append(boots, it.(Contracts.BootInterface))
+		}
+		this.boots = BooterUniqueArrayOf(boots...)
+		return nil
+}
+
+// 依赖是否已注册的服务提供器 或者 容器中已存在
+func (this *ApplicationImpl) isKnownDependency(name string) bool {
+		if _, ok := this.providers[name]; ok {
+				return true
+		}
+		return this.Exists(name)
 }
 
 // each register
@@ -480,7 +716,7 @@ func (this *ApplicationImpl) foreachBoot() func(key, value interface{}) bool {
 func (this *ApplicationImpl) Profiles() map[string]interface{} {
 		var profiles = make(map[string]interface{})
 		if props, ok := this.properties.Load(defaultPropsKey); ok {
-				if v, ok := props.(ApplicationProps); ok {
+				if v, ok := props.(*ApplicationProps); ok {
 						v.Foreach(func(key string, value interface{}) bool {
 								profiles[key] = value
 								return true
@@ -511,31 +747,57 @@ func (this *ApplicationImpl) GetProfile(key string) interface{} {
 
 // 启动服务器监听逻辑
 func (this *ApplicationImpl) StarUp() {
-		var (
-				ok bool
-				ch chan int
-		)
-		v := this.GetProfile(ctrlChan)
+		var ch = this.controlChannel()
 		// 是否运行
 		stop := this.GetProfile(HelpStop)
 		if s, ok := stop.(bool); ok && s {
 				this.Stop()
 				return
 		}
-
-		if v == nil {
-				v = make(chan int, 2)
+		// 控制台命令, 执行后退出
+		if code, ok := this.HandleCommand(os.Stdout); ok {
+				if code != Components.ExitSuccess {
+						os.Exit(code)
+				}
+				return
+		}
+		// 后台运行, 父进程 检查单实例锁 后 fork, 输出子进程 pid 后返回
+		if this.daemonRequested() {
+				if err := this.checkInstance(); err != nil {
+						fmt.Fprintln(os.Stderr, err)
+						os.Exit(1)
+				}
+				pid, err := this.daemonize()
+				if err != nil {
+						fmt.Fprintln(os.Stderr, err)
+						os.Exit(1)
+				}
+				fmt.Printf("started in background, pid %d\n", pid)
+				return
 		}
-		if ch, ok = v.(chan int); ok {
-				this.properties.Store(ctrlChan, ch)
+		// 核心服务加载后 获取单实例锁, 已有实例运行时 不启动自定义服务
+		if err := this.LoadCoreProviders(); err != nil {
+				os.Exit(this.fail(err))
+		}
+		if err := this.lockInstance(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				this.Stop()
+				os.Exit(1)
 		}
 		//  providers
-		this.providersInit()
+		if err := this.providersInit(); err != nil {
+				os.Exit(this.fail(err))
+		}
+		this.Emit(AppStartedEv, ch)
 		this.Emit(StartEv, ch)
 		ticker := time.NewTicker(3 * time.Second)
+		signals, stopNotify := this.notifySignals()
+		defer stopNotify()
 		// 等待结束
 		for {
 				select {
+				case sig := <-signals:
+						this.handleSignal(sig)
 				case signal := <-ch:
 						if signal == -1 {
 								close(ch)
@@ -543,22 +805,46 @@ func (this *ApplicationImpl) StarUp() {
 								return
 						}
 				case <-ticker.C:
-						health := this.Get(Contracts.AppHealth)
-						if fn, ok := health.(func()); ok {
-								fn()
-						}
+						this.health()
 				}
 		}
 }
 
-// 停止服务
+// 控制通道, 未设置时 创建
+func (this *ApplicationImpl) controlChannel() chan int {
+		ch, ok := this.GetProfile(ctrlChan).(chan int)
+		if !ok {
+				ch = make(chan int, 2)
+		}
+		this.properties.Store(ctrlChan, ch)
+		return ch
+}
+
+// 定时健康检查, 结果缓存于 HealthRegistry
+func (this *ApplicationImpl) health() {
+		health := this.Get(Contracts.AppHealth)
+		if fn, ok := health.(func()); ok {
+				fn()
+		}
+		if registry, ok := this.Get(Components.HealthRegistryProviderClass).(Components.HealthRegistry); ok {
+				registry.Run()
+		}
+}
+
+// 停止服务, 按启动逆序销毁服务提供器和单例
 func (this *ApplicationImpl) Stop() {
 		ch, ok := this.properties.Load(ctrlChan)
 		if !ok {
 				return
 		}
 		if ch1, ok := ch.(chan int); ok {
-				ch1 <- -1
-				this.Emit(StopEv, ch)
+				this.stopOnce.Do(func() {
+						this.fire(terminatingHook)
+						this.Emit(AppStoppedEv, ch)
+						this.Emit(StopEv, ch)
+						this.shutdown()
+						this.unlockInstance()
+						ch1 <- -1
+				})
 		}
 }
@@ -0,0 +1,410 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"io"
+		"os"
+		"path/filepath"
+		"regexp"
+		"strings"
+)
+
+const (
+		CompletionCommand = "completion"
+)
+
+// 选项值 补全方式
+const (
+		fileCompletion = "file"
+		dirCompletion  = "dir"
+		modeCompletion = "mode"
+)
+
+var (
+		// 全局选项 值补全方式, 其余选项 值不补全
+		optionCompletions = map[string]string{
+				"appFile": fileCompletion,
+				"reader":  fileCompletion,
+				"paths":   dirCompletion,
+				"mode":    modeCompletion,
+		}
+		completionIdent  = regexp.MustCompile(`\W`)
+		completionShells = []string{"bash", "zsh", "fish"}
+)
+
+// 补全选项
+type completionOption struct {
+		shorts []string
+		long   string
+		tip    string
+		value  bool
+		array  bool
+		kind   string
+		values []string
+}
+
+// 补全参数
+type completionArgument struct {
+		name     string
+		tip      string
+		required bool
+		array    bool
+		values   []string
+}
+
+// 补全命令
+type completionCommand struct {
+		name      string
+		tip       string
+		options   []*completionOption
+		arguments []*completionArgument
+}
+
+// 补全脚本 描述, 由全局选项 及已注册命令 生成
+type completionSpec struct {
+		program  string
+		options  []*completionOption
+		commands []*completionCommand
+}
+
+// completion 命令, 输出 bash/zsh/fish 补全脚本
+func (this *ApplicationImpl) completion(input *Components.ConsoleInput, out io.Writer) int {
+		var spec = this.completionSpec()
+		switch shell := input.Argument("shell"); shell {
+		case "bash":
+				spec.bash(out)
+		case "zsh":
+				spec.zsh(out)
+		case "fish":
+				spec.fish(out)
+		default:
+				fmt.Fprintf(out, "unsupported shell %q, expected bash, zsh or fish\n", shell)
+				return Components.ExitUsage
+		}
+		return Components.ExitSuccess
+}
+
+func (this *ApplicationImpl) completionSpec() *completionSpec {
+		var spec = &completionSpec{program: filepath.Base(os.Args[0]), options: globalCompletionOptions()}
+		commander, ok := this.Get(Components.CommandLineProviderClass).(Components.Commander)
+		if !ok {
+				return spec
+		}
+		for _, command := range commander.Commands() {
+				var it = &completionCommand{name: command.Name, tip: command.Description}
+				if sig := command.Signature; sig != nil {
+						for _, arg := range sig.Arguments {
+								it.arguments = append(it.arguments, &completionArgument{name: arg.Name, tip: arg.Description, required: arg.Required, array: arg.Array})
+						}
+						for _, opt := range sig.Options {
+								var option = &completionOption{long: opt.Name, tip: opt.Description, value: opt.Value, array: opt.Array}
+								if opt.Shortcut != "" {
+										option.shorts = []string{opt.Shortcut}
+								}
+								if opt.Value && opt.Type == Components.BoolValue {
+										option.values = []string{"true", "false"}
+								}
+								it.options = append(it.options, option)
+						}
+				}
+				it.options = append(it.options, &completionOption{long: "help", shorts: []string{"h"}, tip: "display help for the command"})
+				spec.commands = append(spec.commands, it)
+		}
+		// 内置命令 首个参数取值
+		for _, command := range spec.commands {
+				if len(command.arguments) == 0 {
+						continue
+				}
+				switch command.name {
+				case CompletionCommand:
+						command.arguments[0].values = completionShells
+				case Components.HelpCommand:
+						command.arguments[0].values = spec.commandNames()
+				}
+		}
+		return spec
+}
+
+// 全局选项, 来自 optionsMapper
+func globalCompletionOptions() []*completionOption {
+		var items []*completionOption
+		for _, spec := range OptionSpecs() {
+				var option = &completionOption{tip: spec.Tip, value: spec.Value(), array: spec.Multi, kind: optionCompletions[spec.Key]}
+				for _, name := range spec.Flags {
+						if strings.HasPrefix(name, "--") {
+								option.long = strings.TrimPrefix(name, "--")
+						} else {
+								option.shorts = append(option.shorts, strings.TrimPrefix(name, "-"))
+						}
+				}
+				if option.kind == modeCompletion {
+						option.values = GetSupportRunModes()
+				}
+				items = append(items, option)
+		}
+		return items
+}
+
+// 选项名 含前缀
+func (this *completionOption) flags() []string {
+		var flags []string
+		for _, short := range this.shorts {
+				flags = append(flags, "-"+short)
+		}
+		if this.long != "" {
+				flags = append(flags, "--"+this.long)
+		}
+		return flags
+}
+
+func (this *completionSpec) ident() string {
+		return completionIdent.ReplaceAllString(this.program, "_")
+}
+
+func (this *completionSpec) commandNames() []string {
+		var names []string
+		for _, command := range this.commands {
+				names = append(names, command.name)
+		}
+		return names
+}
+
+func optionFlags(options []*completionOption) []string {
+		var flags []string
+		for _, option := range options {
+				flags = append(flags, option.flags()...)
+		}
+		return flags
+}
+
+// 单引号 转义
+func shellQuote(s string) string {
+		return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+func (this *completionSpec) bash(out io.Writer) {
+		var fn = "_" + this.ident() + "_completion"
+		fmt.Fprintf(out, "# bash completion for %s, generated by `%s completion bash`\n", this.program, this.program)
+		fmt.Fprintf(out, "%s() {\n", fn)
+		fmt.Fprint(out, `    local line="${COMP_LINE:0:COMP_POINT}" words=() cur prev command="" i
+    read -ra words <<< "$line"
+    if [[ $line == *[[:space:]] || ${#words[@]} -eq 0 ]]; then
+        words+=("")
+    fi
+    cur="${words[${#words[@]}-1]}"
+    prev=""
+    if [[ ${#words[@]} -gt 1 ]]; then
+        prev="${words[${#words[@]}-2]}"
+    fi
+    if [[ ${#words[@]} -gt 2 && ${words[1]} != -* ]]; then
+        command="${words[1]}"
+    fi
+    if [[ $cur == -*=* ]]; then
+        prev="${cur%%=*}"
+        cur="${cur#*=}"
+    fi
+    COMPREPLY=()
+`)
+		fmt.Fprint(out, "    case \"$prev\" in\n")
+		for _, option := range this.options {
+				if !option.value {
+						continue
+				}
+				fmt.Fprintf(out, "        %s)\n", strings.Join(option.flags(), "|"))
+				switch {
+				case option.kind == fileCompletion:
+						fmt.Fprint(out, "            COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+				case option.kind == dirCompletion:
+						fmt.Fprint(out, "            COMPREPLY=($(compgen -d -- \"$cur\"))\n")
+				case len(option.values) > 0:
+						fmt.Fprintf(out, "            COMPREPLY=($(compgen -W %s -- \"$cur\"))\n", shellQuote(strings.Join(option.values, " ")))
+				}
+				fmt.Fprint(out, "            return\n            ;;\n")
+		}
+		fmt.Fprint(out, "    esac\n    case \"$command\" in\n")
+		fmt.Fprintf(out, `        "")
+            if [[ $cur == -* ]]; then
+                COMPREPLY=($(compgen -W %s -- "$cur"))
+            elif [[ ${#words[@]} -eq 2 ]]; then
+                COMPREPLY=($(compgen -W %s -- "$cur"))
+            fi
+            ;;
+`, shellQuote(strings.Join(optionFlags(this.options), " ")), shellQuote(strings.Join(this.commandNames(), " ")))
+		for _, command := range this.commands {
+				fmt.Fprintf(out, "        %s)\n", shellQuote(command.name))
+				for _, option := range command.options {
+						if !option.value {
+								continue
+						}
+						fmt.Fprintf(out, "            case \"$prev\" in\n                %s)\n", strings.Join(option.flags(), "|"))
+						if len(option.values) > 0 {
+								fmt.Fprintf(out, "                    COMPREPLY=($(compgen -W %s -- \"$cur\"))\n", shellQuote(strings.Join(option.values, " ")))
+						}
+						fmt.Fprint(out, "                    return\n                    ;;\n            esac\n")
+				}
+				fmt.Fprintf(out, "            if [[ $cur == -* ]]; then\n                COMPREPLY=($(compgen -W %s -- \"$cur\"))\n",
+						shellQuote(strings.Join(optionFlags(command.options), " ")))
+				if len(command.arguments) > 0 && len(command.arguments[0].values) > 0 {
+						fmt.Fprintf(out, "            elif [[ ${#words[@]} -eq 3 ]]; then\n                COMPREPLY=($(compgen -W %s -- \"$cur\"))\n",
+								shellQuote(strings.Join(command.arguments[0].values, " ")))
+				}
+				fmt.Fprint(out, "            fi\n            ;;\n")
+		}
+		fmt.Fprint(out, `    esac
+    if [[ $cur == *:* && $COMP_WORDBREAKS == *:* ]]; then
+        local prefix="${cur%"${cur##*:}"}"
+        for i in "${!COMPREPLY[@]}"; do
+            COMPREPLY[i]="${COMPREPLY[i]#"$prefix"}"
+        done
+    fi
+}
+`)
+		fmt.Fprintf(out, "complete -o default -F %s %s\n", fn, this.program)
+}
+
+// zsh _arguments 描述 转义
+func zshEscape(s string) string {
+		return strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, ":", `\:`, "'", `'\''`).Replace(s)
+}
+
+// zsh _arguments 选项描述
+func (this *completionOption) zsh() string {
+		var (
+				flags  = this.flags()
+				names  = flags
+				prefix string
+				spec   string
+		)
+		if this.value {
+				names = make([]string, len(flags))
+				for i, flag := range flags {
+						names[i] = flag + "="
+				}
+		}
+		switch {
+		case this.array:
+				prefix = "*"
+		case len(flags) > 1:
+				prefix = "(" + strings.Join(flags, " ") + ")"
+		}
+		if len(names) > 1 {
+				spec = "{" + strings.Join(names, ",") + "}'"
+				if prefix != "" {
+						spec = "'" + prefix + "'" + spec
+				}
+		} else {
+				spec = "'" + prefix + names[0]
+		}
+		spec += "[" + zshEscape(this.tip) + "]"
+		if this.value {
+				spec += ":" + zshEscape(this.long) + ":"
+				switch {
+				case this.kind == fileCompletion:
+						spec += "_files"
+				case this.kind == dirCompletion:
+						spec += "_files -/"
+				case len(this.values) > 0:
+						spec += "(" + strings.Join(this.values, " ") + ")"
+				}
+		}
+		return spec + "'"
+}
+
+// zsh _arguments 位置参数描述
+func (this *completionArgument) zsh(position int) string {
+		var message = zshEscape(this.name) + ":"
+		if len(this.values) > 0 {
+				message += "(" + strings.Join(this.values, " ") + ")"
+		}
+		switch {
+		case this.array:
+				return "'*:" + message + "'"
+		case this.required:
+				return fmt.Sprintf("'%d:%s'", position, message)
+		default:
+				return fmt.Sprintf("'%d::%s'", position, message)
+		}
+}
+
+func (this *completionSpec) zsh(out io.Writer) {
+		var fn = "_" + this.ident()
+		fmt.Fprintf(out, "#compdef %s\n# zsh completion for %s, generated by `%s completion zsh`\n\n", this.program, this.program, this.program)
+		fmt.Fprintf(out, "%s() {\n    local context state state_descr line\n    typeset -A opt_args\n    _arguments -C \\\n", fn)
+		for _, option := range this.options {
+				fmt.Fprintf(out, "        %s \\\n", option.zsh())
+		}
+		fmt.Fprint(out, "        '1: :->command' \\\n        '*:: :->args'\n    case $state in\n        command)\n            local -a commands\n            commands=(\n")
+		for _, command := range this.commands {
+				fmt.Fprintf(out, "                '%s:%s'\n", zshEscape(command.name), strings.Replace(command.tip, "'", `'\''`, -1))
+		}
+		fmt.Fprint(out, "            )\n            _describe -t commands command commands\n            ;;\n        args)\n            case $line[1] in\n")
+		for _, command := range this.commands {
+				fmt.Fprintf(out, "                %s)\n                    _arguments \\\n", shellQuote(command.name))
+				var specs []string
+				for _, option := range command.options {
+						specs = append(specs, option.zsh())
+				}
+				for i, arg := range command.arguments {
+						specs = append(specs, arg.zsh(i+1))
+				}
+				fmt.Fprintf(out, "                        %s\n                    ;;\n", strings.Join(specs, " \\\n                        "))
+		}
+		fmt.Fprint(out, "            esac\n            ;;\n    esac\n}\n\n")
+		fmt.Fprintf(out, "if [ \"$funcstack[1]\" = \"%s\" ]; then\n    %s \"$@\"\nelse\n    compdef %s %s\nfi\n", fn, fn, fn, this.program)
+}
+
+// fish complete 选项参数
+func (this *completionOption) fish() string {
+		var args []string
+		for _, short := range this.shorts {
+				if len(short) == 1 {
+						args = append(args, "-s", short)
+				} else {
+						args = append(args, "-o", short)
+				}
+		}
+		if this.long != "" {
+				args = append(args, "-l", this.long)
+		}
+		if this.value {
+				switch {
+				case this.kind == fileCompletion:
+						args = append(args, "-r", "-F")
+				case this.kind == dirCompletion:
+						args = append(args, "-x", "-a", "'(__fish_complete_directories)'")
+				case len(this.values) > 0:
+						args = append(args, "-x", "-a", shellQuote(strings.Join(this.values, " ")))
+				default:
+						args = append(args, "-x")
+				}
+		}
+		return strings.Join(append(args, "-d", shellQuote(this.tip)), " ")
+}
+
+func (this *completionSpec) fish(out io.Writer) {
+		var (
+				ident   = this.ident()
+				noCmd   = "__" + ident + "_no_command"
+				usingFn = "__" + ident + "_using_command"
+		)
+		fmt.Fprintf(out, "# fish completion for %s, generated by `%s completion fish`\n", this.program, this.program)
+		fmt.Fprintf(out, "function %s\n    set -l words (commandline -opc)\n    test (count $words) -eq 1\nend\n\n", noCmd)
+		fmt.Fprintf(out, "function %s\n    set -l words (commandline -opc)\n    test (count $words) -gt 1; and test \"$words[2]\" = \"$argv[1]\"\nend\n\n", usingFn)
+		fmt.Fprintf(out, "complete -c %s -f\n", this.program)
+		for _, option := range this.options {
+				fmt.Fprintf(out, "complete -c %s -n %s %s\n", this.program, noCmd, option.fish())
+		}
+		for _, command := range this.commands {
+				fmt.Fprintf(out, "complete -c %s -n %s -a %s -d %s\n", this.program, noCmd, shellQuote(command.name), shellQuote(command.tip))
+		}
+		for _, command := range this.commands {
+				var condition = shellQuote(usingFn + " " + command.name)
+				for _, option := range command.options {
+						fmt.Fprintf(out, "complete -c %s -n %s %s\n", this.program, condition, option.fish())
+				}
+				if len(command.arguments) > 0 && len(command.arguments[0].values) > 0 {
+						fmt.Fprintf(out, "complete -c %s -n %s -a %s\n", this.program, condition, shellQuote(strings.Join(command.arguments[0].values, " ")))
+				}
+		}
+}
@@ -0,0 +1,58 @@
+package Supports
+
+import (
+		"bytes"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"io"
+		"os"
+		"testing"
+)
+
+func TestApplicationCompletion(t *testing.T) {
+		var (
+				app  = newTestApp()
+				args = os.Args
+		)
+		os.Args = []string{"kits"}
+		defer func() {
+				os.Args = args
+		}()
+		app.Register(Components.NewCommandLineArgsProvider())
+		app.InitRegisters()
+		app.registerCommands()
+		commander := app.Get(Components.CommandLineProviderClass).(Components.Commander)
+		_ = commander.Define("user:create {name} {--admin} {--R|role=*}", "create user", func(input *Components.ConsoleInput, out io.Writer) int {
+				return Components.ExitSuccess
+		})
+		var generate = func(shell string) (string, int) {
+				var buf bytes.Buffer
+				code := commander.Call(CompletionCommand, []string{shell}, &buf)
+				return buf.String(), code
+		}
+		Convey("Application Completion Test", t, func() {
+				out, code := generate("bash")
+				So(code, ShouldEqual, Components.ExitSuccess)
+				So(out, ShouldContainSubstring, "complete -o default -F _kits_completion kits")
+				So(out, ShouldContainSubstring, "-m|--mode)\n            COMPREPLY=($(compgen -W 'dev test local prod stg'")
+				So(out, ShouldContainSubstring, "-p|--paths)\n            COMPREPLY=($(compgen -d")
+				So(out, ShouldContainSubstring, "-f|-c|--file)\n            COMPREPLY=($(compgen -f")
+				So(out, ShouldContainSubstring, "'user:create')")
+				So(out, ShouldContainSubstring, "'--admin -R --role -h --help'")
+
+				out, _ = generate("zsh")
+				So(out, ShouldContainSubstring, "#compdef kits")
+				So(out, ShouldContainSubstring, "'user\\:create:create user'")
+				So(out, ShouldContainSubstring, "'*'{-R=,--role=}'[]:role:'")
+				So(out, ShouldContainSubstring, "'1:name:'")
+				So(out, ShouldContainSubstring, "'1:shell:(bash zsh fish)'")
+
+				out, _ = generate("fish")
+				So(out, ShouldContainSubstring, "complete -c kits -n __kits_no_command -s m -l mode -x -a 'dev test local prod stg' -d '运行环境'")
+				So(out, ShouldContainSubstring, "complete -c kits -n '__kits_using_command user:create' -s R -l role -x -d ''")
+
+				out, code = generate("tcsh")
+				So(code, ShouldEqual, Components.ExitUsage)
+				So(out, ShouldContainSubstring, "unsupported shell")
+		})
+}
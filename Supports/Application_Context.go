@@ -0,0 +1,220 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs/Errors"
+		"reflect"
+)
+
+// 容器解析器 (应用, 作用域, 上下文)
+type containerResolver interface {
+		Contracts.ApplicationContainer
+		scopedFactory(id string) func(Contracts.ApplicationContainer) interface{}
+		resolveIn(id string, stack []string) (interface{}, error)
+		trace(stack []string, id string, kind string)
+		resolveFor(id string, typ reflect.Type) (interface{}, bool)
+		taggedIds(tag string) []string
+		contextual(consumer string, id string, container Contracts.ApplicationContainer) (interface{}, bool)
+		hasContextual(consumer string) bool
+		extend(id string, obj interface{}, container Contracts.ApplicationContainer) interface{}
+		afterResolved(id string, obj interface{}, container Contracts.ApplicationContainer)
+}
+
+// 上下文绑定构造器
+type contextualBuilder struct {
+		app      *ApplicationImpl
+		consumer string
+		abstract string
+}
+
+// 上下文容器, consumer 解析依赖时 优先使用上下文绑定
+type contextualContainer struct {
+		containerResolver
+		consumer string
+}
+
+// 给服务打标签
+// eg: app.Tag([]string{"RedisHealth", "MysqlHealth"}, "health.check")
+func (this *ApplicationImpl) Tag(ids []string, tags ...string) {
+		this.container.Tag(ids, tags...)
+}
+
+// 解析标签下的所有服务
+func (this *ApplicationImpl) Tagged(tag string) []interface{} {
+		return tagged(this, tag)
+}
+
+func (this *ApplicationImpl) taggedIds(tag string) []string {
+		return this.container.Tagged(tag)
+}
+
+// 上下文绑定
+// consumer 服务 id 或者 结构体类型名
+// eg: app.When("ReportService").Needs("logger").Give(fileLogger)
+func (this *ApplicationImpl) When(consumer string) Contracts.ContextualBindingBuilder {
+		return &contextualBuilder{app: this, consumer: consumer}
+}
+
+func (this *ApplicationImpl) contextual(consumer string, id string, container Contracts.ApplicationContainer) (interface{}, bool) {
+		this.contextMutex.RLock()
+		obj, ok := this.contexts[consumer][id]
+		this.contextMutex.RUnlock()
+		if !ok {
+				return nil, false
+		}
+		if factory := InstanceOfFactory(obj); factory != nil {
+				return factory(container), true
+		}
+		return obj, true
+}
+
+func (this *ApplicationImpl) hasContextual(consumer string) bool {
+		this.contextMutex.RLock()
+		defer this.contextMutex.RUnlock()
+		return len(this.contexts[consumer]) > 0
+}
+
+func (this *contextualBuilder) Needs(abstract string) Contracts.ContextualBindingNeeds {
+		return &contextualBuilder{app: this.app, consumer: this.consumer, abstract: abstract}
+}
+
+// implementation 对象 或者 工厂 func(Contracts.ApplicationContainer) interface{}
+func (this *contextualBuilder) Give(implementation interface{}) {
+		if this.consumer == "" || this.abstract == "" {
+				return
+		}
+		this.app.contextMutex.Lock()
+		defer this.app.contextMutex.Unlock()
+		if this.app.contexts[this.consumer] == nil {
+				this.app.contexts[this.consumer] = make(map[string]interface{})
+		}
+		this.app.contexts[this.consumer][this.abstract] = implementation
+}
+
+func (this *contextualContainer) Get(id string) interface{} {
+		obj, _ := this.Resolve(id)
+		return obj
+}
+
+func (this *contextualContainer) Resolve(id string) (interface{}, error) {
+		if obj, ok := this.containerResolver.contextual(this.consumer, id, this.containerResolver); ok {
+				return obj, nil
+		}
+		return this.containerResolver.Resolve(id)
+}
+
+func (this *contextualContainer) Fill(obj interface{}) error {
+		return fill(this, obj, this.consumer)
+}
+
+func (this *contextualContainer) Make(target interface{}, ids ...string) error {
+		return makeTarget(this, target, ids...)
+}
+
+func (this *contextualContainer) resolveFor(id string, typ reflect.Type) (interface{}, bool) {
+		if id != "" {
+				obj := this.Get(id)
+				return obj, obj != nil
+		}
+		return this.containerResolver.resolveFor(id, typ)
+}
+
+// consumer 存在上下文绑定时 包装容器
+func withContext(container containerResolver, consumer string) Contracts.ApplicationContainer {
+		if !container.hasContextual(consumer) {
+				return container
+		}
+		return &contextualContainer{containerResolver: container, consumer: consumer}
+}
+
+// 按 inject tag 注入, 上下文绑定 按 consumers 和 结构体类型名 查找
+func fill(container containerResolver, obj interface{}, consumers ...string) error {
+		if name := typeName(obj); name != "" {
+				consumers = append(consumers, name)
+		}
+		return Components.NewInjector(Components.InjectTag).Autowire(obj, func(id string, typ reflect.Type) (interface{}, bool) {
+				if id != "" {
+						for _, consumer := range consumers {
+								if obj, ok := container.contextual(consumer, id, container); ok {
+										return obj, obj != nil
+								}
+						}
+				}
+				return container.resolveFor(id, typ)
+		})
+}
+
+// 解析服务到目标
+func makeTarget(container containerResolver, target interface{}, ids ...string) error {
+		if target == nil || reflect.TypeOf(target).Kind() != reflect.Ptr {
+				return Errors.TypeError("make target must be ptr")
+		}
+		var (
+				id       string
+				value    = reflect.ValueOf(target).Elem()
+				injector = Components.NewInjector(Components.InjectTag)
+		)
+		if len(ids) == 0 && value.Kind() == reflect.Struct {
+				return container.Fill(target)
+		}
+		if len(ids) > 0 {
+				id = ids[0]
+		}
+		obj, ok := container.resolveFor(id, value.Type())
+		if !ok {
+				return Errors.UnresolvableError(injector.Describe(id, value.Type()))
+		}
+		if !injector.Assign(value, obj) {
+				return Errors.TypeError(fmt.Sprintf("%s can not assign to %s", reflect.TypeOf(obj), value.Type()))
+		}
+		return nil
+}
+
+// 实例 依赖注入
+func autowire(container containerResolver, consumer string, instance interface{}) {
+		typ := reflect.TypeOf(instance)
+		if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+				return
+		}
+		if err := fill(container, instance, consumer); err != nil && Components.Debug() {
+				fmt.Println(err)
+		}
+}
+
+// 按打标签顺序 解析, 忽略不存在的服务
+func tagged(container containerResolver, tag string) []interface{} {
+		var items []interface{}
+		for _, id := range container.taggedIds(tag) {
+				if obj := container.Get(id); obj != nil {
+						items = append(items, obj)
+				}
+		}
+		return items
+}
+
+func typeName(obj interface{}) string {
+		typ := reflect.TypeOf(obj)
+		for typ != nil && typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+		}
+		if typ == nil {
+				return ""
+		}
+		return typ.Name()
+}
+
+// 执行构造, panic 和 返回的 error 转为解析错误
+func build(id string, constructor func() interface{}) (instance interface{}, err error) {
+		defer func() {
+				if e := recover(); e != nil {
+						instance, err = nil, Errors.ResolutionError(fmt.Sprintf("%s factory panic: %v", id, e))
+				}
+		}()
+		instance = constructor()
+		if e, ok := instance.(error); ok {
+				return nil, Errors.ResolutionError(fmt.Sprintf("%s factory failed: %v", id, e))
+		}
+		return instance, nil
+}
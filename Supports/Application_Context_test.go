@@ -0,0 +1,69 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"testing"
+)
+
+type reportLogger struct {
+		name string
+}
+
+type ReportService struct {
+		Logger *reportLogger `inject:"logger"`
+}
+
+type AuditService struct {
+		Logger *reportLogger `inject:"logger"`
+}
+
+func TestApplicationTagged(t *testing.T) {
+		var app = newTestApp()
+		app.Bind("redis.check", "redis")
+		app.Bind("mysql.check", "mysql")
+		app.Scoped("request.check", func(scope Contracts.ApplicationContainer) interface{} {
+				return "request"
+		})
+		app.Tag([]string{"redis.check", "mysql.check"}, "health")
+		app.Tag([]string{"redis.check", "request.check", "missing.check"}, "health", "ready")
+		Convey("Application Tagged Test", t, func() {
+				So(app.Tagged("health"), ShouldResemble, []interface{}{"redis", "mysql"})
+				So(app.Tagged("ready"), ShouldResemble, []interface{}{"redis"})
+				So(app.Tagged("unknown"), ShouldBeEmpty)
+				scope := app.NewScope()
+				defer scope.End()
+				So(scope.Tagged("ready"), ShouldResemble, []interface{}{"redis", "request"})
+		})
+}
+
+func TestApplicationContextual(t *testing.T) {
+		var app = newTestApp()
+		app.Bind("logger", &reportLogger{name: "default"})
+		app.When("ReportService").Needs("logger").Give(&reportLogger{name: "report"})
+		app.When("report").Needs("logger").Give(func(app Contracts.ApplicationContainer) interface{} {
+				return &reportLogger{name: "factory"}
+		})
+		app.Singleton("report", func(container Contracts.ApplicationContainer) interface{} {
+				return container.Get("logger")
+		})
+		app.Singleton("audit", func(container Contracts.ApplicationContainer) interface{} {
+				return new(AuditService)
+		})
+		Convey("Application Contextual Test", t, func() {
+				report := new(ReportService)
+				So(app.Fill(report), ShouldBeNil)
+				So(report.Logger.name, ShouldEqual, "report")
+				audit := new(AuditService)
+				So(app.Make(audit), ShouldBeNil)
+				So(audit.Logger.name, ShouldEqual, "default")
+				So(app.Get("audit").(*AuditService).Logger.name, ShouldEqual, "default")
+				So(app.Get("report").(*reportLogger).name, ShouldEqual, "factory")
+				So(app.Get("logger").(*reportLogger).name, ShouldEqual, "default")
+				scope := app.NewScope()
+				defer scope.End()
+				scoped := new(ReportService)
+				So(scope.Fill(scoped), ShouldBeNil)
+				So(scoped.Logger.name, ShouldEqual, "report")
+		})
+}
@@ -0,0 +1,114 @@
+package Supports
+
+import (
+		"github.com/webGameLinux/kits/Contracts"
+		"runtime"
+		"strconv"
+		"strings"
+)
+
+// 延迟加载的服务提供器
+type deferredProvider struct {
+		provider Contracts.DeferrableProvider
+		started  bool
+		// 执行加载的 goroutine
+		owner uint64
+		done  chan struct{}
+}
+
+// 登记延迟加载服务提供器
+// 首次解析 Provides() 中任意 id 时 执行 Init/Register/Boot
+func (this *ApplicationImpl) deferRegister(provider Contracts.DeferrableProvider) {
+		var loader = &deferredProvider{provider: provider, done: make(chan struct{})}
+		this.deferredMutex.Lock()
+		defer this.deferredMutex.Unlock()
+		for _, id := range provider.Provides() {
+				if _, ok := this.deferred[id]; ok {
+						continue
+				}
+				this.deferred[id] = loader
+		}
+}
+
+// 是否未加载的延迟服务
+func (this *ApplicationImpl) isDeferred(id string) bool {
+		this.deferredMutex.Lock()
+		defer this.deferredMutex.Unlock()
+		loader, ok := this.deferred[id]
+		return ok && !loader.started
+}
+
+// 加载延迟服务提供器,并发解析时 等待首个加载完成
+// Register/Boot 中 解析自身提供的服务时 不等待, 按已注册的服务 解析
+func (this *ApplicationImpl) loadDeferred(id string) {
+		var gid = goroutineId()
+		this.deferredMutex.Lock()
+		loader, ok := this.deferred[id]
+		if !ok {
+				this.deferredMutex.Unlock()
+				return
+		}
+		if loader.started {
+				this.deferredMutex.Unlock()
+				if loader.owner != gid {
+						<-loader.done
+				}
+				return
+		}
+		loader.started = true
+		loader.owner = gid
+		this.deferredMutex.Unlock()
+
+		defer this.deferredLoaded(loader)
+		var (
+				provider = loader.provider
+				bean     = provider.GetSupportBean()
+		)
+		provider.Init(this)
+		if bean.HasRegister() {
+				this.timing(provider, registerName, provider.Register)
+		}
+		if bean.HasBoot() {
+				this.timing(provider, bootName, provider.Boot)
+				this.addBooted(provider)
+		}
+}
+
+// 加载完成
+func (this *ApplicationImpl) deferredLoaded(loader *deferredProvider) {
+		this.deferredMutex.Lock()
+		for id, it := range this.deferred {
+				if it == loader {
+						delete(this.deferred, id)
+				}
+		}
+		this.deferredMutex.Unlock()
+		close(loader.done)
+}
+
+// 未加载的延迟服务 id
+func (this *ApplicationImpl) DeferredServices() []string {
+		var ids []string
+		this.deferredMutex.Lock()
+		defer this.deferredMutex.Unlock()
+		for id, loader := range this.deferred {
+				if !loader.started {
+						ids = append(ids, id)
+				}
+		}
+		return ids
+}
+
+// 当前 goroutine id, eg: "goroutine 18 [running]:"
+func goroutineId() uint64 {
+		var (
+				buf    [64]byte
+				n      = runtime.Stack(buf[:], false)
+				fields = strings.Fields(string(buf[:n]))
+		)
+		if len(fields) < 2 {
+				return 0
+		}
+		id, _ := strconv.ParseUint(fields[1], 10, 64)
+		return id
+}
@@ -0,0 +1,117 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"sync"
+		"sync/atomic"
+		"testing"
+		"time"
+)
+
+type lazyProvider struct {
+		Components.AppServiceProvider
+		app       Contracts.ApplicationContainer
+		registers int32
+		boots     int32
+}
+
+func (this *lazyProvider) Init(app Contracts.ApplicationContainer) {
+		this.app = app
+}
+
+func (this *lazyProvider) Provides() []string {
+		return []string{"lazy", "lazy.client"}
+}
+
+func (this *lazyProvider) Register() {
+		atomic.AddInt32(&this.registers, 1)
+		time.Sleep(10 * time.Millisecond)
+		this.app.Bind("lazy", this)
+		this.app.Singleton("lazy.client", func(app Contracts.ApplicationContainer) interface{} {
+				return &helloGreeter{Word: "lazy"}
+		})
+}
+
+func (this *lazyProvider) Boot() {
+		atomic.AddInt32(&this.boots, 1)
+}
+
+// Boot 中 解析自身提供的服务
+type cacheProvider struct {
+		lazyProvider
+		store interface{}
+		stats interface{}
+}
+
+func (this *cacheProvider) Provides() []string {
+		return []string{"cache", "cache.store", "cache.stats"}
+}
+
+func (this *cacheProvider) Register() {
+		this.app.Bind("cache.store", "memory")
+		this.app.Singleton("cache", func(app Contracts.ApplicationContainer) interface{} {
+				return "cache:" + app.Get("cache.store").(string)
+		})
+}
+
+func (this *cacheProvider) Boot() {
+		this.store = this.app.Get("cache.store")
+		// 已声明 未注册
+		this.stats = this.app.Get("cache.stats")
+}
+
+func TestApplicationDeferredProvider(t *testing.T) {
+		var (
+				app      = newTestApp()
+				provider = &lazyProvider{AppServiceProvider: Components.AppServiceProvider{Name: "LazyProvider"}}
+		)
+		app.Register(provider)
+		Convey("Deferred Provider Test", t, func() {
+				So(app.Exists("lazy.client"), ShouldBeTrue)
+				So(app.registers.Count(), ShouldEqual, 0)
+				So(len(app.DeferredServices()), ShouldEqual, 2)
+				var (
+						wg      sync.WaitGroup
+						results = make([]interface{}, 20)
+				)
+				for i := 0; i < len(results); i++ {
+						wg.Add(1)
+						go func(i int) {
+								defer wg.Done()
+								results[i] = app.Get("lazy.client")
+						}(i)
+				}
+				wg.Wait()
+				for _, it := range results {
+						So(it, ShouldNotBeNil)
+				}
+				So(atomic.LoadInt32(&provider.registers), ShouldEqual, 1)
+				So(atomic.LoadInt32(&provider.boots), ShouldEqual, 1)
+				So(app.Get("lazy"), ShouldEqual, provider)
+				So(len(app.DeferredServices()), ShouldEqual, 0)
+		})
+}
+
+func TestApplicationDeferredReentrant(t *testing.T) {
+		var (
+				app      = newTestApp()
+				provider = &cacheProvider{lazyProvider: lazyProvider{AppServiceProvider: Components.AppServiceProvider{Name: "CacheProvider"}}}
+				done     = make(chan interface{}, 1)
+		)
+		app.Register(provider)
+		go func() {
+				done <- app.Get("cache")
+		}()
+		Convey("Deferred Provider Reentrant Test", t, func() {
+				select {
+				case obj := <-done:
+						So(obj, ShouldEqual, "cache:memory")
+				case <-time.After(time.Second):
+						So("load cache blocked", ShouldBeEmpty)
+				}
+				So(provider.store, ShouldEqual, "memory")
+				So(provider.stats, ShouldBeNil)
+		})
+}
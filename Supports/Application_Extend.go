@@ -0,0 +1,68 @@
+package Supports
+
+import (
+		"github.com/webGameLinux/kits/Contracts"
+)
+
+// 服务扩展器, 返回值 替换原服务
+type Extender func(original interface{}, app Contracts.ApplicationContainer) interface{}
+
+// 扩展 (装饰) 服务, 按注册顺序执行
+// 绑定: 已绑定时 立即替换, 否则 绑定时执行
+// 单例: 已实例化时 立即替换, 否则 首次解析时执行
+// 作用域: 每个作用域 实例化时执行
+// 别名 与 服务 id 共用扩展器
+func (this *ApplicationImpl) Extend(id string, extender func(interface{}, Contracts.ApplicationContainer) interface{}) {
+		if id == "" || extender == nil {
+				return
+		}
+		id = this.aliasOf(id)
+		this.extendMutex.Lock()
+		this.extenders[id] = append(this.extenders[id], extender)
+		this.extendMutex.Unlock()
+		en := this.container.Resolver(id)
+		if en == nil || en.Extras().Bool(SCOPED) {
+				return
+		}
+		if en.Extras().Bool(SINGLETON) {
+				if obj, ok := en.Extras().Load(SINGLETON_OBJECT); ok {
+						obj = extender(obj, this)
+						this.container.Update(en.key, func(it *entry) {
+								it.Extras().Store(SINGLETON_OBJECT, obj)
+						})
+				}
+				return
+		}
+		value := extender(en.Value(), this)
+		this.container.Update(en.key, func(it *entry) {
+				it.SetValue(value)
+		})
+}
+
+// 执行已注册的扩展器
+func (this *ApplicationImpl) extend(id string, obj interface{}, container Contracts.ApplicationContainer) interface{} {
+		if obj == nil {
+				return nil
+		}
+		id = this.aliasOf(id)
+		this.extendMutex.RLock()
+		extenders := append([]Extender{}, this.extenders[id]...)
+		this.extendMutex.RUnlock()
+		for _, extender := range extenders {
+				obj = extender(obj, container)
+		}
+		return obj
+}
+
+// 别名 解析为 服务 id
+func (this *ApplicationImpl) aliasOf(id string) string {
+		var aliases = this.container.Aliases()
+		for i := 0; i < maxAliasDepth; i++ {
+				clazz, ok := aliases[id]
+				if !ok {
+						break
+				}
+				id = clazz
+		}
+		return id
+}
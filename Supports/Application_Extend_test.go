@@ -0,0 +1,72 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"testing"
+)
+
+func wrapWith(name string) func(interface{}, Contracts.ApplicationContainer) interface{} {
+		return func(original interface{}, app Contracts.ApplicationContainer) interface{} {
+				return original.(string) + "+" + name
+		}
+}
+
+func TestApplicationExtend(t *testing.T) {
+		var (
+				app   = newTestApp()
+				calls int
+		)
+		app.Extend("sink", wrapWith("before"))
+		app.Bind("sink", "file")
+		app.Extend("sink", wrapWith("after"))
+
+		app.Singleton("redis", func(app Contracts.ApplicationContainer) interface{} {
+				calls++
+				return "client"
+		})
+		app.Extend("redis", wrapWith("metrics"))
+		app.Extend("redis", wrapWith("tracing"))
+
+		app.Singleton("cache", func(app Contracts.ApplicationContainer) interface{} {
+				return "memory"
+		})
+		app.Scoped("tx", func(app Contracts.ApplicationContainer) interface{} {
+				return "tx"
+		})
+		app.Extend("tx", wrapWith("logged"))
+		Convey("Application Extend Test", t, func() {
+				So(app.Get("sink"), ShouldEqual, "file+before+after")
+				So(app.Get("redis"), ShouldEqual, "client+metrics+tracing")
+				So(app.Get("redis"), ShouldEqual, "client+metrics+tracing")
+				So(calls, ShouldEqual, 1)
+
+				So(app.Get("cache"), ShouldEqual, "memory")
+				app.Extend("cache", wrapWith("lru"))
+				So(app.Get("cache"), ShouldEqual, "memory+lru")
+
+				scope := app.NewScope()
+				defer scope.End()
+				So(scope.Get("tx"), ShouldEqual, "tx+logged")
+				So(scope.Get("redis"), ShouldEqual, "client+metrics+tracing")
+		})
+}
+
+func TestApplicationExtendAlias(t *testing.T) {
+		var app = newTestApp()
+		app.Singleton("redis", func(app Contracts.ApplicationContainer) interface{} {
+				return "client"
+		})
+		app.Alias("redis", "cache")
+		app.Extend("cache", wrapWith("metrics"))
+		app.Bind("sink", "file")
+		app.Alias("sink", "log.sink")
+		Convey("Application Extend Alias Test", t, func() {
+				So(app.Get("redis"), ShouldEqual, "client+metrics")
+				So(app.Get("cache"), ShouldEqual, "client+metrics")
+				app.Extend("redis", wrapWith("tracing"))
+				So(app.Get("cache"), ShouldEqual, "client+metrics+tracing")
+				app.Extend("log.sink", wrapWith("rotate"))
+				So(app.Get("sink"), ShouldEqual, "file+rotate")
+		})
+}
@@ -0,0 +1,298 @@
+package Supports
+
+import (
+		"bufio"
+		"bytes"
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"go/format"
+		"io"
+		"io/ioutil"
+		"os"
+		"path/filepath"
+		"regexp"
+		"strings"
+		"text/template"
+		"unicode"
+)
+
+const (
+		MakeProviderCommand     = "make:provider"
+		MakeCommandCommand      = "make:command"
+		MakeSchemaCommand       = "make:schema"
+		MakeBootstrapperCommand = "make:bootstrapper"
+		// 默认 服务提供器清单 配置文件
+		defaultManifestFile = "config/app.properties"
+)
+
+var (
+		generatorWord    = regexp.MustCompile(`[A-Za-z0-9]+`)
+		generatorIdent   = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+		generatorPackage = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+		generatorModule  = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+		generatorIndent  = regexp.MustCompile(`(?m)^\t+`)
+)
+
+// 代码生成器, 按模板 生成源文件 及测试桩
+type generator struct {
+		kind     string
+		suffix   string
+		dir      string
+		source   *template.Template
+		test     *template.Template
+		provider bool
+}
+
+// 模板数据
+type generatorData struct {
+		Package     string
+		Name        string
+		Type        string
+		Var         string
+		Command     string
+		Signature   string
+		Description string
+}
+
+var generators = map[string]*generator{
+		MakeProviderCommand: {
+				kind: "provider", suffix: "Provider", dir: "Libs/Providers", provider: true,
+				source: template.Must(template.New("provider").Parse(providerTemplate)),
+				test:   template.Must(template.New("provider_test").Parse(providerTestTemplate)),
+		},
+		MakeCommandCommand: {
+				kind: "command", suffix: "Command", dir: "Libs/Commands", provider: true,
+				source: template.Must(template.New("command").Parse(commandTemplate)),
+				test:   template.Must(template.New("command_test").Parse(commandTestTemplate)),
+		},
+		MakeSchemaCommand: {
+				kind: "schema", dir: "Libs/Schemas",
+				source: template.Must(template.New("schema").Parse(schemaTemplate)),
+				test:   template.Must(template.New("schema_test").Parse(schemaTestTemplate)),
+		},
+		MakeBootstrapperCommand: {
+				kind: "bootstrapper", suffix: "Bootstrapper", dir: "Libs/Bootstrappers",
+				source: template.Must(template.New("bootstrapper").Parse(bootstrapperTemplate)),
+				test:   template.Must(template.New("bootstrapper_test").Parse(bootstrapperTestTemplate)),
+		},
+}
+
+// 注册 make:* 命令
+func (this *ApplicationImpl) registerGenerators(commander Components.Commander) {
+		var (
+				common   = " {--dir=%s : target directory} {--package= : package name, default directory name} {--force : overwrite existing files}"
+				manifest = " {--register : add to provider manifest app.providers} {--manifest=" + defaultManifestFile + " : manifest file}"
+		)
+		_ = commander.Define(MakeProviderCommand+" {name : provider name, eg: Cache}"+fmt.Sprintf(common, generators[MakeProviderCommand].dir)+manifest,
+				"generate a service provider", generators[MakeProviderCommand].handle)
+		_ = commander.Define(MakeCommandCommand+" {name : command name, eg: user:create}"+fmt.Sprintf(common, generators[MakeCommandCommand].dir)+manifest+
+				" {--signature= : arguments and options after the command name} {--description= : command description}",
+				"generate a console command", generators[MakeCommandCommand].handle)
+		_ = commander.Define(MakeSchemaCommand+" {name : schema service name, eg: GameServer}"+fmt.Sprintf(common, generators[MakeSchemaCommand].dir),
+				"generate a supervised schema service", generators[MakeSchemaCommand].handle)
+		_ = commander.Define(MakeBootstrapperCommand+" {name : bootstrapper name, eg: Migrate}"+fmt.Sprintf(common, generators[MakeBootstrapperCommand].dir),
+				"generate an application bootstrapper", generators[MakeBootstrapperCommand].handle)
+}
+
+// make:* 命令
+func (this *generator) handle(input *Components.ConsoleInput, out io.Writer) int {
+		data, err := this.data(input)
+		if err != nil {
+				fmt.Fprintln(out, err)
+				return Components.ExitUsage
+		}
+		files, err := this.generate(input.Option("dir"), data, input.Bool("force"))
+		if err != nil {
+				fmt.Fprintln(out, err)
+				return Components.ExitFailure
+		}
+		for _, file := range files {
+				fmt.Fprintf(out, "created %s\n", file)
+		}
+		if this.provider && input.Bool("register") {
+				var file = input.Option("manifest")
+				added, err := RegisterManifest(file, data.Type)
+				if err != nil {
+						fmt.Fprintln(out, err)
+						return Components.ExitFailure
+				}
+				if added {
+						fmt.Fprintf(out, "registered %s in %s\n", data.Type, file)
+				}
+		}
+		if pkg := importPath(input.Option("dir")); pkg != "" && this.provider {
+				fmt.Fprintf(out, "import _ %q to register the %s\n", pkg, this.kind)
+		}
+		return Components.ExitSuccess
+}
+
+// 模板数据, 校验名称 包名 命令签名
+func (this *generator) data(input *Components.ConsoleInput) (*generatorData, error) {
+		var (
+				name = input.Argument("name")
+				data = &generatorData{Name: GeneratorName(name, this.suffix), Package: input.Option("package")}
+		)
+		if !generatorIdent.MatchString(data.Name) {
+				return nil, fmt.Errorf("invalid %s name %q", this.kind, name)
+		}
+		data.Type = data.Name + this.suffix
+		data.Var = string(unicode.ToLower(rune(data.Name[0]))) + data.Name[1:]
+		if data.Package == "" {
+				data.Package = filepath.Base(filepath.Clean(input.Option("dir")))
+		}
+		if !generatorPackage.MatchString(data.Package) {
+				return nil, fmt.Errorf("invalid package name %q, use --package", data.Package)
+		}
+		if this.kind == "command" {
+				data.Command = name
+				data.Signature = strings.TrimSpace(name + " " + input.Option("signature"))
+				data.Description = input.Option("description")
+				if data.Description == "" {
+						data.Description = name
+				}
+				if _, err := Components.ParseSignature(data.Signature); err != nil {
+						return nil, fmt.Errorf("invalid signature %q: %v", data.Signature, err)
+				}
+		}
+		return data, nil
+}
+
+// 生成 源文件 及测试桩, 已存在的文件 force 时覆盖
+func (this *generator) generate(dir string, data *generatorData, force bool) ([]string, error) {
+		var (
+				files = []string{
+						filepath.Join(dir, data.Type+".go"),
+						filepath.Join(dir, data.Type+"_test.go"),
+				}
+				sources [][]byte
+		)
+		for i, tpl := range []*template.Template{this.source, this.test} {
+				if _, err := os.Stat(files[i]); err == nil && !force {
+						return nil, fmt.Errorf("file %s already exists, use --force to overwrite", files[i])
+				}
+				source, err := renderSource(tpl, data)
+				if err != nil {
+						return nil, err
+				}
+				sources = append(sources, source)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+				return nil, err
+		}
+		for i, file := range files {
+				if err := ioutil.WriteFile(file, sources[i], 0644); err != nil {
+						return nil, err
+				}
+		}
+		return files, nil
+}
+
+// 渲染模板 并格式化, 缩进 与本项目一致
+func renderSource(tpl *template.Template, data *generatorData) ([]byte, error) {
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, data); err != nil {
+				return nil, err
+		}
+		source, err := format.Source(buf.Bytes())
+		if err != nil {
+				return nil, fmt.Errorf("generate %s: %v", tpl.Name(), err)
+		}
+		return generatorIndent.ReplaceAllFunc(source, func(tabs []byte) []byte {
+				return bytes.Repeat(tabs, 2)
+		}), nil
+}
+
+// 类型名, eg: user:create => UserCreate, cache-provider => Cache (去掉 suffix)
+func GeneratorName(name string, suffix string) string {
+		var words []string
+		for _, word := range generatorWord.FindAllString(name, -1) {
+				words = append(words, strings.ToUpper(word[:1])+word[1:])
+		}
+		var ident = strings.Join(words, "")
+		if suffix != "" && ident != suffix {
+				ident = strings.TrimSuffix(ident, suffix)
+		}
+		return ident
+}
+
+// 加入服务提供器清单 app.providers, 文件不存在时 创建, 已存在的名称 返回 false
+// 配置文件的键 以文件名为前缀, 清单文件 须为 app.properties 等, 写入 providers=
+func RegisterManifest(file string, name string) (bool, error) {
+		switch filepath.Ext(file) {
+		case ".properties", ".conf", ".ini", "":
+		default:
+				return false, fmt.Errorf("unsupported manifest file %s, expected key=value format", file)
+		}
+		var scope = Components.GetScope(file) + "."
+		if !strings.HasPrefix(ProvidersManifestKey, scope) {
+				return false, fmt.Errorf("manifest file %s keys are read with prefix %s, use app.properties", file, scope)
+		}
+		var manifestKey = strings.TrimPrefix(ProvidersManifestKey, scope)
+		content, err := ioutil.ReadFile(file)
+		if err != nil && !os.IsNotExist(err) {
+				return false, err
+		}
+		var (
+				lines   []string
+				found   bool
+				scanner = bufio.NewScanner(bytes.NewReader(content))
+		)
+		for scanner.Scan() {
+				var line = scanner.Text()
+				if key, value, ok := manifestLine(line, manifestKey); ok && !found {
+						found = true
+						for _, it := range strings.Split(value, ",") {
+								if strings.EqualFold(strings.TrimSpace(it), name) {
+										return false, nil
+								}
+						}
+						if strings.TrimSpace(value) == "" {
+								line = key + "=" + name
+						} else {
+								line = strings.TrimRight(line, " \t") + "," + name
+						}
+				}
+				lines = append(lines, line)
+		}
+		if !found {
+				lines = append(lines, manifestKey+"="+name)
+		}
+		if err = os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return false, err
+		}
+		return true, ioutil.WriteFile(file, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
+
+// 清单配置行, 注释行 忽略
+func manifestLine(line string, manifestKey string) (string, string, bool) {
+		var trimmed = strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+				return "", "", false
+		}
+		i := strings.IndexAny(trimmed, "=:")
+		if i < 0 || strings.TrimSpace(trimmed[:i]) != manifestKey {
+				return "", "", false
+		}
+		return trimmed[:i], trimmed[i+1:], true
+}
+
+// 目录的导入路径, 由上级 go.mod 推算, 找不到时 返回空
+func importPath(dir string) string {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+				return ""
+		}
+		for root := abs; ; root = filepath.Dir(root) {
+				if content, err := ioutil.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+						match := generatorModule.FindSubmatch(content)
+						if match == nil {
+								return ""
+						}
+						rel, _ := filepath.Rel(root, abs)
+						return strings.TrimSuffix(string(match[1])+"/"+filepath.ToSlash(rel), "/.")
+				}
+				if filepath.Dir(root) == root {
+						return ""
+				}
+		}
+}
@@ -0,0 +1,349 @@
+package Supports
+
+// make:provider 模板
+const providerTemplate = `package {{.Package}}
+
+import (
+	"fmt"
+	"github.com/webGameLinux/kits/Components"
+	"github.com/webGameLinux/kits/Contracts"
+	"sync"
+)
+
+type {{.Type}} interface {
+	Contracts.Provider
+	fmt.Stringer
+}
+
+type {{.Type}}Impl struct {
+	Name  string
+	app   Contracts.ApplicationContainer
+	clazz Contracts.ClazzInterface
+	bean  Contracts.SupportInterface
+}
+
+const (
+	{{.Type}}Class = "{{.Type}}"
+)
+
+var (
+	{{.Var}}InstanceLock sync.Once
+	{{.Var}}Instance     *{{.Type}}Impl
+)
+
+// 注册到服务提供器清单, eg: app.providers={{.Type}}
+func init() {
+	Components.RegisterProvider({{.Type}}Class, func() Contracts.Provider {
+		return New{{.Type}}()
+	})
+}
+
+func {{.Type}}Of() {{.Type}} {
+	if {{.Var}}Instance == nil {
+		{{.Var}}InstanceLock.Do({{.Var}}ProviderNew)
+	}
+	return {{.Var}}Instance
+}
+
+func {{.Var}}ProviderNew() {
+	{{.Var}}Instance = new{{.Type}}()
+}
+
+// 创建独立的服务 (非全局)
+func New{{.Type}}() {{.Type}} {
+	return new{{.Type}}()
+}
+
+func new{{.Type}}() *{{.Type}}Impl {
+	var provider = new({{.Type}}Impl)
+	provider.Name = {{.Type}}Class
+	return provider
+}
+
+func (this *{{.Type}}Impl) GetClazz() Contracts.ClazzInterface {
+	if this.clazz == nil {
+		this.clazz = Components.ClazzOf(this)
+	}
+	return this.clazz
+}
+
+func (this *{{.Type}}Impl) Init(app Contracts.ApplicationContainer) {
+	if this.app == nil {
+		this.app = app
+	}
+}
+
+func (this *{{.Type}}Impl) GetSupportBean() Contracts.SupportInterface {
+	if this.bean == nil {
+		this.bean = Components.BeanOf()
+	}
+	return this.bean
+}
+
+func (this *{{.Type}}Impl) Register() {
+	this.app.Bind(this.String(), this)
+}
+
+func (this *{{.Type}}Impl) Boot() {
+
+}
+
+func (this *{{.Type}}Impl) Constructor() interface{} {
+	return {{.Type}}Of()
+}
+
+func (this *{{.Type}}Impl) Factory(app Contracts.ApplicationContainer) interface{} {
+	this.Init(app)
+	return this
+}
+
+func (this *{{.Type}}Impl) String() string {
+	return this.Name
+}
+`
+
+const providerTestTemplate = `package {{.Package}}
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func Test{{.Type}}(t *testing.T) {
+	Convey("{{.Type}} Test", t, func() {
+		var provider = New{{.Type}}()
+		So(provider.String(), ShouldEqual, {{.Type}}Class)
+		So({{.Type}}Of().String(), ShouldEqual, {{.Type}}Class)
+	})
+}
+`
+
+// make:command 模板, 命令由服务提供器 注册
+const commandTemplate = `package {{.Package}}
+
+import (
+	"github.com/webGameLinux/kits/Components"
+	"github.com/webGameLinux/kits/Contracts"
+	"io"
+)
+
+// {{.Command}} 命令
+type {{.Type}} struct {
+	Name  string
+	app   Contracts.ApplicationContainer
+	clazz Contracts.ClazzInterface
+	bean  Contracts.SupportInterface
+}
+
+const (
+	{{.Type}}Class = "{{.Type}}"
+	// 命令签名, eg: name {arg} {arg?} {--flag} {--opt=default}
+	{{.Name}}Signature   = {{printf "%q" .Signature}}
+	{{.Name}}Description = {{printf "%q" .Description}}
+)
+
+// 注册到服务提供器清单, eg: app.providers={{.Type}}
+func init() {
+	Components.RegisterProvider({{.Type}}Class, func() Contracts.Provider {
+		return New{{.Type}}()
+	})
+}
+
+func New{{.Type}}() *{{.Type}} {
+	var command = new({{.Type}})
+	command.Name = {{.Type}}Class
+	return command
+}
+
+func (this *{{.Type}}) GetClazz() Contracts.ClazzInterface {
+	if this.clazz == nil {
+		this.clazz = Components.ClazzOf(this)
+	}
+	return this.clazz
+}
+
+func (this *{{.Type}}) Init(app Contracts.ApplicationContainer) {
+	if this.app == nil {
+		this.app = app
+	}
+}
+
+func (this *{{.Type}}) GetSupportBean() Contracts.SupportInterface {
+	if this.bean == nil {
+		this.bean = Components.BeanOf()
+	}
+	return this.bean
+}
+
+// 注册命令
+func (this *{{.Type}}) Register() {
+	this.app.Bind(this.String(), this)
+	commander, ok := this.app.Get(Components.CommandLineProviderClass).(Components.Commander)
+	if !ok {
+		return
+	}
+	if err := commander.Define({{.Name}}Signature, {{.Name}}Description, this.Handle); err != nil {
+		panic(err)
+	}
+}
+
+func (this *{{.Type}}) Boot() {
+
+}
+
+// 执行命令, 返回退出码
+func (this *{{.Type}}) Handle(input *Components.ConsoleInput, out io.Writer) int {
+	return Components.ExitSuccess
+}
+
+func (this *{{.Type}}) String() string {
+	return this.Name
+}
+`
+
+const commandTestTemplate = `package {{.Package}}
+
+import (
+	"bytes"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/webGameLinux/kits/Components"
+	"testing"
+)
+
+func Test{{.Type}}(t *testing.T) {
+	Convey("{{.Type}} Test", t, func() {
+		var command = New{{.Type}}()
+		So(command.String(), ShouldEqual, {{.Type}}Class)
+		signature, err := Components.ParseSignature({{.Name}}Signature)
+		So(err, ShouldBeNil)
+		So(signature.Name, ShouldEqual, {{printf "%q" .Command}})
+		var buf bytes.Buffer
+		So(command.Handle(nil, &buf), ShouldEqual, Components.ExitSuccess)
+	})
+}
+`
+
+// make:schema 模板, 由 Supervisor 监管运行
+const schemaTemplate = `package {{.Package}}
+
+import (
+	"context"
+	"github.com/webGameLinux/kits/Contracts"
+	"sync/atomic"
+)
+
+// {{.Type}} 服务
+// 加入 schema 服务: app.Get(Components.SchemaServiceProviderClass).(Components.SchemaServiceProvider).Add(New{{.Type}}())
+type {{.Type}} struct {
+	app   Contracts.ApplicationContainer
+	state int32
+}
+
+const (
+	{{.Type}}Class = "{{.Type}}"
+)
+
+func New{{.Type}}() *{{.Type}} {
+	return new({{.Type}})
+}
+
+func (this *{{.Type}}) Initializer(apps ...Contracts.ApplicationContainer) {
+	if this.app == nil && len(apps) > 0 {
+		this.app = apps[0]
+	}
+}
+
+// 运行状态, 大于 0 时 不再启动
+func (this *{{.Type}}) State() int {
+	return int(atomic.LoadInt32(&this.state))
+}
+
+// 运行服务, ctx 取消时 返回, 返回错误时 按重启策略重启
+func (this *{{.Type}}) Serve(ctx context.Context) error {
+	atomic.StoreInt32(&this.state, 1)
+	defer atomic.StoreInt32(&this.state, 0)
+	<-ctx.Done()
+	return nil
+}
+
+func (this *{{.Type}}) String() string {
+	return {{.Type}}Class
+}
+`
+
+const schemaTestTemplate = `package {{.Package}}
+
+import (
+	"context"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/webGameLinux/kits/Components"
+	"testing"
+)
+
+func Test{{.Type}}(t *testing.T) {
+	Convey("{{.Type}} Test", t, func() {
+		var (
+			schema      = New{{.Type}}()
+			_           Components.SchemaService = schema
+			_           Components.Serviceable   = schema
+			ctx, cancel = context.WithCancel(context.Background())
+		)
+		So(schema.String(), ShouldEqual, {{.Type}}Class)
+		cancel()
+		So(schema.Serve(ctx), ShouldBeNil)
+		So(schema.State(), ShouldEqual, 0)
+	})
+}
+`
+
+// make:bootstrapper 模板
+const bootstrapperTemplate = `package {{.Package}}
+
+import (
+	"github.com/webGameLinux/kits/Contracts"
+)
+
+// {{.Type}} 引导器
+// 加入应用引导: Components.AppBootstrapperOf().Add(New{{.Type}}())
+type {{.Type}} struct {
+	app Contracts.ApplicationContainer
+}
+
+const (
+	{{.Type}}Class = "{{.Type}}"
+)
+
+func New{{.Type}}() *{{.Type}} {
+	return new({{.Type}})
+}
+
+func (this *{{.Type}}) Initializer(apps ...Contracts.ApplicationContainer) {
+	if this.app == nil && len(apps) > 0 {
+		this.app = apps[0]
+	}
+}
+
+func (this *{{.Type}}) Boot() {
+
+}
+
+func (this *{{.Type}}) String() string {
+	return {{.Type}}Class
+}
+`
+
+const bootstrapperTestTemplate = `package {{.Package}}
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/webGameLinux/kits/Components"
+	"testing"
+)
+
+func Test{{.Type}}(t *testing.T) {
+	Convey("{{.Type}} Test", t, func() {
+		var bootstrapper Components.Bootstrapper = New{{.Type}}()
+		So(bootstrapper.String(), ShouldEqual, {{.Type}}Class)
+	})
+}
+`
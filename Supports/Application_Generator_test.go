@@ -0,0 +1,138 @@
+package Supports
+
+import (
+		"bytes"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"go/parser"
+		"go/token"
+		"io/ioutil"
+		"os"
+		"path/filepath"
+		"testing"
+)
+
+func TestGeneratorName(t *testing.T) {
+		Convey("Generator Name Test", t, func() {
+				So(GeneratorName("cache", "Provider"), ShouldEqual, "Cache")
+				So(GeneratorName("CacheProvider", "Provider"), ShouldEqual, "Cache")
+				So(GeneratorName("user:create", "Command"), ShouldEqual, "UserCreate")
+				So(GeneratorName("game-server", ""), ShouldEqual, "GameServer")
+				So(GeneratorName("Provider", "Provider"), ShouldEqual, "Provider")
+				So(GeneratorName("1st", ""), ShouldEqual, "1st")
+		})
+}
+
+func TestRegisterManifest(t *testing.T) {
+		dir, err := ioutil.TempDir("", "kits-manifest")
+		if err != nil {
+				t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		Convey("Register Manifest Test", t, func() {
+				var file = filepath.Join(dir, "config", "app.properties")
+				added, err := RegisterManifest(file, "CacheProvider")
+				So(err, ShouldBeNil)
+				So(added, ShouldBeTrue)
+				content, _ := ioutil.ReadFile(file)
+				So(string(content), ShouldEqual, "providers=CacheProvider\n")
+
+				So(ioutil.WriteFile(file, []byte("name=kits\n# providers=IrisHttpServer\nproviders = IrisHttpServer\n"), 0644), ShouldBeNil)
+				added, err = RegisterManifest(file, "CacheProvider")
+				So(err, ShouldBeNil)
+				So(added, ShouldBeTrue)
+				added, err = RegisterManifest(file, "cacheprovider")
+				So(err, ShouldBeNil)
+				So(added, ShouldBeFalse)
+				content, _ = ioutil.ReadFile(file)
+				So(string(content), ShouldEqual, "name=kits\n# providers=IrisHttpServer\nproviders = IrisHttpServer,CacheProvider\n")
+
+				_, err = RegisterManifest(filepath.Join(dir, "app.yml"), "CacheProvider")
+				So(err, ShouldNotBeNil)
+				_, err = RegisterManifest(filepath.Join(dir, "providers.properties"), "CacheProvider")
+				So(err, ShouldNotBeNil)
+		})
+}
+
+func TestApplicationGenerators(t *testing.T) {
+		var (
+				app  = newTestApp()
+				args = os.Args
+		)
+		os.Args = []string{"kits"}
+		defer func() {
+				os.Args = args
+		}()
+		dir, err := ioutil.TempDir("", "kits-generator")
+		if err != nil {
+				t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		app.Register(Components.NewCommandLineArgsProvider())
+		app.InitRegisters()
+		app.registerCommands()
+		commander := app.Get(Components.CommandLineProviderClass).(Components.Commander)
+		var (
+				target = filepath.Join(dir, "Providers")
+				call   = func(name string, args ...string) (string, int) {
+						var buf bytes.Buffer
+						code := commander.Call(name, append(args, "--dir="+target), &buf)
+						return buf.String(), code
+				}
+				parse = func(name string) string {
+						content, err := ioutil.ReadFile(filepath.Join(target, name))
+						So(err, ShouldBeNil)
+						_, err = parser.ParseFile(token.NewFileSet(), name, content, parser.AllErrors)
+						So(err, ShouldBeNil)
+						return string(content)
+				}
+		)
+		Convey("Application Generators Test", t, func() {
+				for _, name := range []string{MakeProviderCommand, MakeCommandCommand, MakeSchemaCommand, MakeBootstrapperCommand} {
+						So(commander.Lookup(name), ShouldNotBeNil)
+				}
+
+				out, code := call(MakeProviderCommand, "cache", "--register", "--manifest="+filepath.Join(dir, "app.properties"))
+				So(code, ShouldEqual, Components.ExitSuccess)
+				So(out, ShouldContainSubstring, "created "+filepath.Join(target, "CacheProvider.go"))
+				So(out, ShouldContainSubstring, "registered CacheProvider in ")
+				source := parse("CacheProvider.go")
+				So(source, ShouldStartWith, "package Providers\n")
+				So(source, ShouldContainSubstring, "\n\t\tComponents.RegisterProvider(CacheProviderClass, func() Contracts.Provider {")
+				So(source, ShouldContainSubstring, "func (this *CacheProviderImpl) Register() {")
+				So(parse("CacheProvider_test.go"), ShouldContainSubstring, "func TestCacheProvider(t *testing.T) {")
+
+				out, code = call(MakeProviderCommand, "cache")
+				So(code, ShouldEqual, Components.ExitFailure)
+				So(out, ShouldContainSubstring, "already exists, use --force to overwrite")
+				_, code = call(MakeProviderCommand, "cache", "--force")
+				So(code, ShouldEqual, Components.ExitSuccess)
+
+				_, code = call(MakeCommandCommand, "user:create", "--signature={id} {--force}", "--description=create user")
+				So(code, ShouldEqual, Components.ExitSuccess)
+				source = parse("UserCreateCommand.go")
+				So(source, ShouldContainSubstring, `UserCreateSignature   = "user:create {id} {--force}"`)
+				So(source, ShouldContainSubstring, "commander.Define(UserCreateSignature, UserCreateDescription, this.Handle)")
+				parse("UserCreateCommand_test.go")
+				out, code = call(MakeCommandCommand, "user:delete", "--signature={id?} {name}")
+				So(code, ShouldEqual, Components.ExitUsage)
+				So(out, ShouldContainSubstring, "invalid signature")
+
+				_, code = call(MakeSchemaCommand, "game-server")
+				So(code, ShouldEqual, Components.ExitSuccess)
+				So(parse("GameServer.go"), ShouldContainSubstring, "func (this *GameServer) Serve(ctx context.Context) error {")
+				parse("GameServer_test.go")
+
+				_, code = call(MakeBootstrapperCommand, "migrate")
+				So(code, ShouldEqual, Components.ExitSuccess)
+				So(parse("MigrateBootstrapper.go"), ShouldContainSubstring, "func (this *MigrateBootstrapper) Boot() {")
+				parse("MigrateBootstrapper_test.go")
+
+				out, code = call(MakeSchemaCommand, "1st")
+				So(code, ShouldEqual, Components.ExitUsage)
+				So(out, ShouldContainSubstring, `invalid schema name "1st"`)
+				out, code = call(MakeSchemaCommand, "worker", "--package=my-pkg")
+				So(code, ShouldEqual, Components.ExitUsage)
+				So(out, ShouldContainSubstring, `invalid package name "my-pkg"`)
+		})
+}
@@ -0,0 +1,179 @@
+package Supports
+
+import (
+		"encoding/json"
+		"fmt"
+		"github.com/webGameLinux/kits/Libs/Errors"
+		"reflect"
+		"strconv"
+		"strings"
+		"sync"
+)
+
+// 解析中容器, 传给工厂, 记录解析栈 检测循环依赖
+type resolvingContainer struct {
+		containerResolver
+		stack []string
+		err   error
+		mutex sync.Mutex
+}
+
+// 依赖图节点
+type DependencyNode struct {
+		Id   string `json:"id"`
+		Kind string `json:"kind"`
+}
+
+// 依赖图边, From 解析时依赖 To
+type DependencyEdge struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+}
+
+// 依赖图
+type DependencyGraph struct {
+		Nodes []DependencyNode `json:"nodes"`
+		Edges []DependencyEdge `json:"edges"`
+}
+
+// 解析记录
+type resolutionGraph struct {
+		nodes []DependencyNode
+		index map[string]int
+		edges []DependencyEdge
+		seen  map[DependencyEdge]bool
+		mutex sync.Mutex
+}
+
+func newResolutionGraph() *resolutionGraph {
+		var graph = new(resolutionGraph)
+		graph.index = make(map[string]int)
+		graph.seen = make(map[DependencyEdge]bool)
+		return graph
+}
+
+// 根据已记录的解析 生成依赖图
+func (this *ApplicationImpl) DependencyGraph() *DependencyGraph {
+		var graph = this.resolutions
+		graph.mutex.Lock()
+		defer graph.mutex.Unlock()
+		return &DependencyGraph{
+				Nodes: append([]DependencyNode{}, graph.nodes...),
+				Edges: append([]DependencyEdge{}, graph.edges...),
+		}
+}
+
+func (this *ApplicationImpl) trace(stack []string, id string, kind string) {
+		this.resolutions.add(stack, id, kind)
+}
+
+func (this *scopeContainer) trace(stack []string, id string, kind string) {
+		this.parent.trace(stack, id, kind)
+}
+
+func (this *resolutionGraph) add(stack []string, id string, kind string) {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		if i, ok := this.index[id]; !ok {
+				this.index[id] = len(this.nodes)
+				this.nodes = append(this.nodes, DependencyNode{Id: id, Kind: kind})
+		} else if this.nodes[i].Kind == "" {
+				this.nodes[i].Kind = kind
+		}
+		if len(stack) == 0 {
+				return
+		}
+		var edge = DependencyEdge{From: stack[len(stack)-1], To: id}
+		if !this.seen[edge] {
+				this.seen[edge] = true
+				this.edges = append(this.edges, edge)
+		}
+}
+
+// graphviz dot 格式
+func (this *DependencyGraph) DOT() string {
+		var (
+				buf    strings.Builder
+				shapes = map[string]string{BIND: "box", SINGLETON: "ellipse", SCOPED: "hexagon"}
+		)
+		buf.WriteString("digraph dependencies {\n")
+		for _, node := range this.Nodes {
+				shape, ok := shapes[node.Kind]
+				if !ok {
+						shape = "ellipse"
+				}
+				buf.WriteString(fmt.Sprintf("\t%s [shape=%s];\n", strconv.Quote(node.Id), shape))
+		}
+		for _, edge := range this.Edges {
+				buf.WriteString(fmt.Sprintf("\t%s -> %s;\n", strconv.Quote(edge.From), strconv.Quote(edge.To)))
+		}
+		buf.WriteString("}\n")
+		return buf.String()
+}
+
+// json 格式
+func (this *DependencyGraph) JSON() ([]byte, error) {
+		return json.Marshal(this)
+}
+
+// 工厂使用的容器, 解析栈 追加当前服务
+func resolving(container containerResolver, stack []string, id string) *resolvingContainer {
+		var path = make([]string, len(stack), len(stack)+1)
+		copy(path, stack)
+		return &resolvingContainer{containerResolver: container, stack: append(path, id)}
+}
+
+func (this *resolvingContainer) Get(id string) interface{} {
+		obj, _ := this.Resolve(id)
+		return obj
+}
+
+func (this *resolvingContainer) Resolve(id string) (interface{}, error) {
+		obj, err := this.containerResolver.resolveIn(id, this.stack)
+		if Errors.IsCircularError(err) {
+				this.mutex.Lock()
+				if this.err == nil {
+						this.err = err
+				}
+				this.mutex.Unlock()
+		}
+		return obj, err
+}
+
+func (this *resolvingContainer) Fill(obj interface{}) error {
+		return fill(this, obj)
+}
+
+func (this *resolvingContainer) Make(target interface{}, ids ...string) error {
+		return makeTarget(this, target, ids...)
+}
+
+func (this *resolvingContainer) Tagged(tag string) []interface{} {
+		return tagged(this, tag)
+}
+
+func (this *resolvingContainer) resolveFor(id string, typ reflect.Type) (interface{}, bool) {
+		if id != "" {
+				obj := this.Get(id)
+				return obj, obj != nil
+		}
+		return this.containerResolver.resolveFor(id, typ)
+}
+
+// 构造过程中 出现的循环依赖错误
+func (this *resolvingContainer) failed() error {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		return this.err
+}
+
+// 解析栈中已存在 id 时 返回循环依赖错误, eg: A -> B -> A
+func circular(stack []string, id string) error {
+		for i, it := range stack {
+				if it == id {
+						var path = append(append([]string{}, stack[i:]...), id)
+						return Errors.CircularError(strings.Join(path, " -> "))
+				}
+		}
+		return nil
+}
@@ -0,0 +1,109 @@
+package Supports
+
+import (
+		"encoding/json"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"testing"
+)
+
+type reportService struct {
+		Logger interface{} `inject:"logger"`
+		Self   interface{} `inject:"report"`
+}
+
+func TestApplicationCircular(t *testing.T) {
+		var (
+				app   = newTestApp()
+				calls int
+		)
+		app.Singleton("A", func(app Contracts.ApplicationContainer) interface{} {
+				calls++
+				return []interface{}{app.Get("B")}
+		})
+		app.Singleton("B", func(app Contracts.ApplicationContainer) interface{} {
+				return []interface{}{app.Get("alias.A")}
+		})
+		app.Alias("A", "alias.A")
+		app.Singleton("self", func(app Contracts.ApplicationContainer) interface{} {
+				return app.Get("self")
+		})
+		app.Scoped("tx", func(app Contracts.ApplicationContainer) interface{} {
+				return app.Get("tx")
+		})
+		app.Bind("logger", "stdout")
+		app.Singleton("report", func(app Contracts.ApplicationContainer) interface{} {
+				return new(reportService)
+		})
+		Convey("Application Circular Test", t, func() {
+				obj, err := app.Resolve("A")
+				So(obj, ShouldBeNil)
+				So(err.Error(), ShouldContainSubstring, "A -> B -> A")
+				_, err = app.Resolve("A")
+				So(err.Error(), ShouldContainSubstring, "A -> B -> A")
+				So(calls, ShouldEqual, 1)
+				_, err = app.Resolve("self")
+				So(err.Error(), ShouldContainSubstring, "self -> self")
+				scope := app.NewScope()
+				_, err = scope.Resolve("tx")
+				So(err.Error(), ShouldContainSubstring, "tx -> tx")
+				scope.End()
+				// 字段注入自身 不阻塞, 字段保持为空
+				report := app.Get("report").(*reportService)
+				So(report.Logger, ShouldEqual, "stdout")
+				So(report.Self, ShouldBeNil)
+		})
+}
+
+func TestApplicationOuterResolve(t *testing.T) {
+		var app = newTestApp()
+		// 工厂 调用外部 app, 如 服务提供器 Init 时保存的 app, 不记录依赖边
+		app.Singleton("C", func(Contracts.ApplicationContainer) interface{} {
+				return "C"
+		})
+		app.Singleton("D", func(Contracts.ApplicationContainer) interface{} {
+				return "D:" + app.Get("C").(string)
+		})
+		Convey("Application Outer Resolve Test", t, func() {
+				So(app.Get("D"), ShouldEqual, "D:C")
+				So(app.Get("D"), ShouldEqual, "D:C")
+		})
+}
+
+func TestApplicationDependencyGraph(t *testing.T) {
+		var app = newTestApp()
+		app.Bind("config", "file")
+		app.Singleton("redis", func(app Contracts.ApplicationContainer) interface{} {
+				return "redis:" + app.Get("config").(string)
+		})
+		app.Singleton("cache", func(app Contracts.ApplicationContainer) interface{} {
+				return "cache:" + app.Get("redis").(string)
+		})
+		app.Scoped("session", func(app Contracts.ApplicationContainer) interface{} {
+				return "session:" + app.Get("cache").(string)
+		})
+		scope := app.NewScope()
+		scope.Get("session")
+		scope.End()
+		Convey("Application Dependency Graph Test", t, func() {
+				graph := app.DependencyGraph()
+				So(graph.Nodes, ShouldResemble, []DependencyNode{
+						{Id: "session", Kind: SCOPED},
+						{Id: "cache", Kind: SINGLETON},
+						{Id: "redis", Kind: SINGLETON},
+						{Id: "config", Kind: BIND},
+				})
+				So(graph.Edges, ShouldResemble, []DependencyEdge{
+						{From: "session", To: "cache"},
+						{From: "cache", To: "redis"},
+						{From: "redis", To: "config"},
+				})
+				So(graph.DOT(), ShouldContainSubstring, `"cache" -> "redis";`)
+				So(graph.DOT(), ShouldContainSubstring, `"config" [shape=box];`)
+				data, err := graph.JSON()
+				So(err, ShouldBeNil)
+				var decoded DependencyGraph
+				So(json.Unmarshal(data, &decoded), ShouldBeNil)
+				So(decoded.Edges, ShouldResemble, graph.Edges)
+		})
+}
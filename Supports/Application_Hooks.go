@@ -0,0 +1,127 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Contracts"
+		"sync"
+)
+
+const (
+		bootingHook     = "booting"
+		bootedHook      = "booted"
+		terminatingHook = "terminating"
+)
+
+// 生命周期钩子
+type lifecycleHooks struct {
+		phases    map[string][]func(Contracts.ApplicationContainer)
+		fired     map[string]bool
+		resolving map[string][]func(interface{}, Contracts.ApplicationContainer)
+		mutex     sync.Mutex
+}
+
+func newLifecycleHooks() *lifecycleHooks {
+		var hooks = new(lifecycleHooks)
+		hooks.phases = make(map[string][]func(Contracts.ApplicationContainer))
+		hooks.fired = make(map[string]bool)
+		hooks.resolving = make(map[string][]func(interface{}, Contracts.ApplicationContainer))
+		return hooks
+}
+
+// 服务提供器引导前 (InitBoots) 执行, 已触发时 立即执行
+func (this *ApplicationImpl) Booting(fn func(Contracts.ApplicationContainer)) {
+		this.hook(bootingHook, fn)
+}
+
+// 全部服务提供器引导后 (StarUp) 执行, 已引导时 立即执行
+func (this *ApplicationImpl) Booted(fn func(Contracts.ApplicationContainer)) {
+		this.hook(bootedHook, fn)
+}
+
+// 停止 (Stop) 销毁服务前 执行, 已触发时 立即执行
+func (this *ApplicationImpl) Terminating(fn func(Contracts.ApplicationContainer)) {
+		this.hook(terminatingHook, fn)
+}
+
+// 单例/作用域服务 每次实例化后 执行, 单例已实例化时 立即执行
+func (this *ApplicationImpl) AfterResolving(id string, fn func(interface{}, Contracts.ApplicationContainer)) {
+		if id == "" || fn == nil {
+				return
+		}
+		if entry := this.container.Resolver(id); entry != nil {
+				id = entry.key
+		}
+		this.hooks.mutex.Lock()
+		this.hooks.resolving[id] = append(this.hooks.resolving[id], fn)
+		this.hooks.mutex.Unlock()
+		if entry := this.container.Resolver(id); entry != nil && entry.Extras().Bool(SINGLETON) {
+				if obj, ok := entry.Extras().Load(SINGLETON_OBJECT); ok && obj != nil {
+						fn(obj, this)
+				}
+		}
+}
+
+// 登记阶段钩子, 已触发的阶段 立即执行
+func (this *ApplicationImpl) hook(phase string, fn func(Contracts.ApplicationContainer)) {
+		if fn == nil {
+				return
+		}
+		this.hooks.mutex.Lock()
+		if this.hooks.fired[phase] {
+				this.hooks.mutex.Unlock()
+				this.runHook(phase, fn)
+				return
+		}
+		this.hooks.phases[phase] = append(this.hooks.phases[phase], fn)
+		this.hooks.mutex.Unlock()
+}
+
+// 触发阶段钩子, 每个阶段只触发一次
+func (this *ApplicationImpl) fire(phase string) {
+		this.hooks.mutex.Lock()
+		if this.hooks.fired[phase] {
+				this.hooks.mutex.Unlock()
+				return
+		}
+		this.hooks.fired[phase] = true
+		fns := this.hooks.phases[phase]
+		delete(this.hooks.phases, phase)
+		this.hooks.mutex.Unlock()
+		for _, fn := range fns {
+				this.runHook(phase, fn)
+		}
+}
+
+// 执行钩子
+func (this *ApplicationImpl) runHook(phase string, fn func(Contracts.ApplicationContainer)) {
+		if phase == terminatingHook {
+				this.safeHook(phase, fn)
+				return
+		}
+		fn(this)
+}
+
+// 停止阶段 钩子 panic 时 记录日志, 不中断销毁
+func (this *ApplicationImpl) safeHook(phase string, fn func(Contracts.ApplicationContainer)) {
+		defer func() {
+				if err := recover(); err != nil {
+						this.logger().Error(fmt.Sprintf("%s hook panic: %v", phase, err))
+				}
+		}()
+		fn(this)
+}
+
+// 执行实例化后 钩子
+func (this *ApplicationImpl) afterResolved(id string, obj interface{}, container Contracts.ApplicationContainer) {
+		this.hooks.mutex.Lock()
+		fns := append([]func(interface{}, Contracts.ApplicationContainer){}, this.hooks.resolving[id]...)
+		this.hooks.mutex.Unlock()
+		for _, fn := range fns {
+				fn(obj, container)
+		}
+}
+
+// 作用域实例化后 执行父容器登记的钩子
+func (this *scopeContainer) afterResolved(id string, obj interface{}, container Contracts.ApplicationContainer) {
+		this.parent.afterResolved(id, obj, container)
+}
@@ -0,0 +1,85 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"testing"
+)
+
+func TestApplicationLifecycleHooks(t *testing.T) {
+		var (
+				app    = NewApp()
+				events []string
+				record = func(name string) func(Contracts.ApplicationContainer) {
+						return func(Contracts.ApplicationContainer) {
+								events = append(events, name)
+						}
+				}
+		)
+		app.Bind(Components.EnvironmentProviderBootPrepare, func(app Contracts.ApplicationContainer) {
+				events = append(events, "prepare")
+		})
+		app.Booting(record("booting"))
+		app.Booted(record("booted"))
+		app.Terminating(record("terminating"))
+		app.Terminating(func(Contracts.ApplicationContainer) {
+				panic("terminating failed")
+		})
+		app.Terminating(record("terminated"))
+		Convey("Application Lifecycle Hooks Test", t, func() {
+				app.providersInit()
+				So(events, ShouldResemble, []string{"booting", "prepare", "booted"})
+
+				app.Booted(record("late"))
+				So(events[len(events)-1], ShouldEqual, "late")
+				// 已触发的阶段 立即执行
+				app.Booting(record("late booting"))
+				So(events[len(events)-1], ShouldEqual, "late booting")
+
+				app.properties.Store(ctrlChan, make(chan int, 2))
+				app.Stop()
+				app.Stop()
+				So(events[len(events)-2:], ShouldResemble, []string{"terminating", "terminated"})
+				app.Terminating(func(Contracts.ApplicationContainer) {
+						panic("late terminating failed")
+				})
+				app.Terminating(record("late terminating"))
+				So(events[len(events)-1], ShouldEqual, "late terminating")
+		})
+}
+
+func TestApplicationAfterResolving(t *testing.T) {
+		var (
+				app      = newTestApp()
+				resolved []interface{}
+				hook     = func(obj interface{}, app Contracts.ApplicationContainer) {
+						resolved = append(resolved, obj)
+				}
+		)
+		app.Singleton("greeter", func(app Contracts.ApplicationContainer) interface{} {
+				return &helloGreeter{Word: "hello"}
+		})
+		app.Alias("greeter", "hello.greeter")
+		app.Scoped("request", func(app Contracts.ApplicationContainer) interface{} {
+				return &helloGreeter{Word: "request"}
+		})
+		app.AfterResolving("hello.greeter", hook)
+		app.AfterResolving("request", hook)
+		Convey("Application After Resolving Test", t, func() {
+				greeter := app.Get("greeter")
+				app.Get("greeter")
+				So(resolved, ShouldResemble, []interface{}{greeter})
+
+				app.AfterResolving("greeter", hook)
+				So(len(resolved), ShouldEqual, 2)
+
+				scope := app.NewScope()
+				request := scope.Get("request")
+				scope.Get("request")
+				app.NewScope().Get("request")
+				So(len(resolved), ShouldEqual, 4)
+				So(resolved[2], ShouldEqual, request)
+				scope.End()
+		})
+}
@@ -0,0 +1,267 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"io"
+		"os"
+		"sort"
+		"strings"
+		"text/tabwriter"
+		"time"
+)
+
+const (
+		ContainerListCommand = "container:list"
+		ProvidersListCommand = "providers:list"
+		AppProfilesCommand   = "app:profiles"
+		maskedValue          = "******"
+)
+
+// 敏感配置关键字, app:profiles 输出时 屏蔽
+var secretWords = []string{"password", "passwd", "pwd", "secret", "token", "credential", "private", "apikey", "api_key", "access_key", "dsn"}
+
+// 控制台内核
+type ConsoleKernel interface {
+		HandleCommand(out io.Writer) (int, bool)
+}
+
+// 服务提供器 注册/引导 耗时
+type providerTiming struct {
+		register   time.Duration
+		boot       time.Duration
+		registered bool
+		booted     bool
+}
+
+// 记录服务提供器 注册/引导 耗时
+func (this *ApplicationImpl) timing(provider interface{}, phase string, fn func()) {
+		var start = time.Now()
+		fn()
+		var (
+				elapsed = time.Since(start)
+				name    = ProviderName(provider)
+		)
+		this.timingMutex.Lock()
+		defer this.timingMutex.Unlock()
+		it, ok := this.timings[name]
+		if !ok {
+				it = new(providerTiming)
+				this.timings[name] = it
+		}
+		switch phase {
+		case registerName:
+				it.register, it.registered = elapsed, true
+		case bootName:
+				it.boot, it.booted = elapsed, true
+		}
+}
+
+// 注册内置 控制台命令
+func (this *ApplicationImpl) registerCommands() {
+		commander, ok := this.Get(Components.CommandLineProviderClass).(Components.Commander)
+		if !ok {
+				return
+		}
+		commander.Add(ContainerListCommand, "list container services, aliases and resolve state", this.containerList)
+		commander.Add(ProvidersListCommand, "list service providers with register/boot timing", this.providersList)
+		commander.Add(AppProfilesCommand, "dump application profiles, secrets masked", this.appProfiles)
+		commander.Add(AppStatusCommand, "show whether an instance is running, by pid file", this.appStatus)
+		commander.Add(AppStopCommand, "stop the running instance gracefully, by pid file", this.appStop)
+		_ = commander.Define(CompletionCommand+" {shell : bash, zsh or fish}", "generate shell completion script", this.completion)
+		this.registerGenerators(commander)
+}
+
+// 执行命令行请求的控制台命令
+func (this *ApplicationImpl) runCommand(out io.Writer) (int, bool) {
+		commander, ok := this.Get(Components.CommandLineProviderClass).(Components.Commander)
+		if !ok {
+				return 0, false
+		}
+		command, args := commander.Requested()
+		if command == nil {
+				return 0, false
+		}
+		return commander.Call(command.Name, commandArguments(args), out), true
+}
+
+// 去除已由 Properties 解析的全局选项, 其余参数 交由命令处理
+func commandArguments(args []string) []string {
+		parsed, err := ParseOptions(args, commandOptionSpecs(), false)
+		if err != nil {
+				return args
+		}
+		return parsed.Args()
+}
+
+// 控制台内核: 全局选项后 首个参数为已注册命令时 加载服务提供器 执行命令 并返回退出码
+// 未指定命令 或命令未注册时 返回 false, 由 StarUp 启动服务; 命令 需在服务提供器 Register 时定义
+func (this *ApplicationImpl) HandleCommand(out io.Writer) (int, bool) {
+		lookup, _ := this.GetProfile(Contracts.AppCommandOptions).(Components.OptionLookup)
+		if Components.CommandName(os.Args, lookup) == "" {
+				return 0, false
+		}
+		this.controlChannel()
+		if err := this.LoadCoreProviders(); err != nil {
+				return this.fail(err), true
+		}
+		if !Components.RunningCommand(this) {
+				return 0, false
+		}
+		if err := this.providersInit(); err != nil {
+				return this.fail(err), true
+		}
+		code, ok := this.runCommand(out)
+		this.Stop()
+		if !ok {
+				fmt.Fprintln(out, "console commands unavailable")
+				code = Components.ExitFailure
+		}
+		return code, true
+}
+
+func (this *ApplicationImpl) containerList(args []string, out io.Writer) int {
+		var (
+				writer  = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+				aliases = make(map[string][]string)
+		)
+		for alias, id := range this.container.Aliases() {
+				aliases[id] = append(aliases[id], alias)
+		}
+		fmt.Fprintln(writer, "ID\tKIND\tRESOLVED\tTYPE\tALIASES")
+		for _, id := range this.container.Keys() {
+				entry := this.container.Resolver(id)
+				if entry == nil {
+						continue
+				}
+				var (
+						kind     = BIND
+						resolved = "yes"
+						typ      = fmt.Sprintf("%T", entry.Value())
+				)
+				switch {
+				case entry.Extras().Bool(SCOPED):
+						kind, resolved = SCOPED, "-"
+				case entry.Extras().Bool(SINGLETON):
+						kind, resolved = SINGLETON, "no"
+						if obj, ok := entry.Extras().Load(SINGLETON_OBJECT); ok {
+								resolved, typ = "yes", fmt.Sprintf("%T", obj)
+						}
+				}
+				sort.Strings(aliases[id])
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", id, kind, resolved, typ, strings.Join(aliases[id], ","))
+		}
+		this.deferredMutex.Lock()
+		var deferred = make(map[string]string)
+		for id, loader := range this.deferred {
+				deferred[id] = ProviderName(loader.provider)
+		}
+		this.deferredMutex.Unlock()
+		for _, id := range sortedKeys(deferred) {
+				fmt.Fprintf(writer, "%s\tdeferred\tno\t%s\t\n", id, deferred[id])
+		}
+		_ = writer.Flush()
+		return 0
+}
+
+func (this *ApplicationImpl) providersList(args []string, out io.Writer) int {
+		var writer = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, "CLASS\tREGISTER\tBOOT\tREGISTER TIME\tBOOT TIME\tDEFERRED")
+		for _, name := range this.providerNames {
+				var (
+						provider = this.providers[name]
+						bean     = provider.GetSupportBean()
+						class    = name
+						deferred = "no"
+				)
+				if clazz := provider.GetClazz(); clazz != nil && clazz.String() != "" {
+						class = clazz.String()
+				}
+				if this.isDeferredProvider(provider) {
+						deferred = "yes"
+				}
+				this.timingMutex.Lock()
+				var it = this.timings[name]
+				if it == nil {
+						it = new(providerTiming)
+				}
+				fmt.Fprintf(writer, "%s\t%t\t%t\t%s\t%s\t%s\n", class, bean.HasRegister(), bean.HasBoot(),
+						elapsed(it.registered, it.register), elapsed(it.booted, it.boot), deferred)
+				this.timingMutex.Unlock()
+		}
+		_ = writer.Flush()
+		return 0
+}
+
+func (this *ApplicationImpl) appProfiles(args []string, out io.Writer) int {
+		var (
+				writer   = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+				profiles = this.Profiles()
+				keys     = make([]string, 0, len(profiles))
+		)
+		for key := range profiles {
+				keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+				fmt.Fprintf(writer, "%s\t%s\n", key, profileValue(key, profiles[key]))
+		}
+		_ = writer.Flush()
+		return 0
+}
+
+// 是否延迟加载 (未加载) 的服务提供器
+func (this *ApplicationImpl) isDeferredProvider(provider interface{}) bool {
+		this.deferredMutex.Lock()
+		defer this.deferredMutex.Unlock()
+		for _, loader := range this.deferred {
+				if loader.provider == provider {
+						return true
+				}
+		}
+		return false
+}
+
+// 配置值 输出格式, 敏感配置 屏蔽
+func profileValue(key string, value interface{}) string {
+		if isSecret(key) && value != nil && fmt.Sprint(value) != "" {
+				return maskedValue
+		}
+		switch value.(type) {
+		case nil:
+				return ""
+		case string, bool, int, int64, float64, time.Duration, []string:
+				return fmt.Sprint(value)
+		}
+		if str, ok := value.(fmt.Stringer); ok {
+				return str.String()
+		}
+		return fmt.Sprintf("%T", value)
+}
+
+func isSecret(key string) bool {
+		key = strings.ToLower(key)
+		for _, word := range secretWords {
+				if strings.Contains(key, word) {
+						return true
+				}
+		}
+		return false
+}
+
+func elapsed(done bool, duration time.Duration) string {
+		if !done {
+				return "-"
+		}
+		return duration.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+		var keys = make([]string, 0, len(m))
+		for key := range m {
+				keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+}
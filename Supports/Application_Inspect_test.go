@@ -0,0 +1,151 @@
+package Supports
+
+import (
+		"bytes"
+		"fmt"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"io"
+		"os"
+		"strings"
+		"testing"
+)
+
+// 按列拆分输出行
+func inspectRows(out string) map[string][]string {
+		var rows = make(map[string][]string)
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) > 0 {
+						rows[fields[0]] = fields
+				}
+		}
+		return rows
+}
+
+func TestApplicationInspectCommands(t *testing.T) {
+		var (
+				app  = newTestApp()
+				args = os.Args
+				lazy = &lazyProvider{AppServiceProvider: Components.AppServiceProvider{Name: "LazyProvider"}}
+		)
+		os.Args = []string{"kits", ContainerListCommand, "--mode=test"}
+		defer func() {
+				os.Args = args
+		}()
+		app.Register(Components.NewCommandLineArgsProvider())
+		app.Register(lazy)
+		app.InitRegisters()
+		app.registerCommands()
+		app.Bind("hello", &helloGreeter{Word: "hello"})
+		app.Alias("hello", "greeter")
+		app.Singleton("redis", func(app Contracts.ApplicationContainer) interface{} {
+				return &redisClient{}
+		})
+		app.Singleton("mysql", func(app Contracts.ApplicationContainer) interface{} {
+				return &redisClient{}
+		})
+		app.Scoped("tx", func(app Contracts.ApplicationContainer) interface{} {
+				return "tx"
+		})
+		app.Get("redis")
+		app.properties.Store("redis.password", "p@ss")
+		app.properties.Store("redis.addr", "127.0.0.1:6379")
+		Convey("Application Inspect Commands Test", t, func() {
+				var buf bytes.Buffer
+				code, ok := app.runCommand(&buf)
+				So(ok, ShouldBeTrue)
+				So(code, ShouldEqual, 0)
+				rows := inspectRows(buf.String())
+				So(rows["hello"], ShouldResemble, []string{"hello", BIND, "yes", "*Supports.helloGreeter", "greeter"})
+				So(rows["redis"], ShouldResemble, []string{"redis", SINGLETON, "yes", "*Supports.redisClient"})
+				So(rows["mysql"][1:3], ShouldResemble, []string{SINGLETON, "no"})
+				So(rows["tx"][1:3], ShouldResemble, []string{SCOPED, "-"})
+				So(rows["lazy.client"][1:4], ShouldResemble, []string{"deferred", "no", "LazyProvider"})
+
+				buf.Reset()
+				So(app.providersList(nil, &buf), ShouldEqual, 0)
+				rows = inspectRows(buf.String())
+				So(rows[Components.CommandLineProviderClass][1:3], ShouldResemble, []string{"true", "true"})
+				So(rows[Components.CommandLineProviderClass][4], ShouldEqual, "-")
+				So(rows["LazyProvider"][5], ShouldEqual, "yes")
+
+				buf.Reset()
+				So(app.appProfiles(nil, &buf), ShouldEqual, 0)
+				rows = inspectRows(buf.String())
+				So(rows["redis.password"][1], ShouldEqual, maskedValue)
+				So(rows["redis.addr"][1], ShouldEqual, "127.0.0.1:6379")
+				So(buf.String(), ShouldNotContainSubstring, "p@ss")
+		})
+}
+
+// 注册时 定义签名命令的服务提供器
+type greetCommandProvider struct {
+		Components.AppServiceProvider
+		app Contracts.ApplicationContainer
+}
+
+func (this *greetCommandProvider) Init(app Contracts.ApplicationContainer) {
+		this.app = app
+}
+
+func (this *greetCommandProvider) Register() {
+		commander := this.app.Get(Components.CommandLineProviderClass).(Components.Commander)
+		_ = commander.Define("greet {name} {--times:int=1}", "greet someone", func(input *Components.ConsoleInput, out io.Writer) int {
+				fmt.Fprint(out, strings.Repeat("hello "+input.Argument("name")+";", input.Int("times")))
+				return Components.ExitSuccess
+		})
+}
+
+func TestApplicationHandleCommand(t *testing.T) {
+		var args = os.Args
+		defer func() {
+				os.Args = args
+		}()
+		var app *ApplicationImpl
+		var handle = func(argv ...string) (string, int, bool) {
+				var buf bytes.Buffer
+				app = newTestApp()
+				WithCommandOptions(globalOption)(app)
+				os.Args = argv
+				app.properties.Store(ctrlChan, make(chan int, 2))
+				app.Register(Components.NewCommandLineArgsProvider())
+				app.Register(&greetCommandProvider{AppServiceProvider: Components.AppServiceProvider{Name: "GreetCommandProvider"}})
+				code, ok := app.HandleCommand(&buf)
+				return buf.String(), code, ok
+		}
+		Convey("Application Handle Command Test", t, func() {
+				_, _, ok := handle("kits", "--mode=dev")
+				So(ok, ShouldBeFalse)
+
+				out, code, ok := handle("kits", "greet", "tom", "--times=2")
+				So(ok, ShouldBeTrue)
+				So(code, ShouldEqual, Components.ExitSuccess)
+				So(out, ShouldEqual, "hello tom;hello tom;")
+
+				out, code, _ = handle("kits", "greet", "--times=x", "tom")
+				So(code, ShouldEqual, Components.ExitUsage)
+				So(out, ShouldContainSubstring, `invalid int value "x"`)
+
+				out, code, _ = handle("kits", "greet", "-h")
+				So(code, ShouldEqual, Components.ExitSuccess)
+				So(out, ShouldContainSubstring, "greet [options] [--] <name>")
+
+				out, code, _ = handle("kits", "help", "greet")
+				So(code, ShouldEqual, Components.ExitSuccess)
+				So(out, ShouldContainSubstring, "--times=TIMES")
+
+				out, code, ok = handle("kits", "--mode", "stg", "greet", "tom")
+				So(ok, ShouldBeTrue)
+				So(code, ShouldEqual, Components.ExitSuccess)
+				So(out, ShouldEqual, "hello tom;")
+
+				// 未注册的命令名 由 StarUp 启动服务, 核心服务 不重复加载
+				_, _, ok = handle("kits", "gret")
+				So(ok, ShouldBeFalse)
+				So(app.isInit(registerName), ShouldBeTrue)
+				So(app.isInit(bootName), ShouldBeTrue)
+				So(func() { app.LoadCoreProviders() }, ShouldNotPanic)
+		})
+}
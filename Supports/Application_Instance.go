@@ -0,0 +1,229 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"io"
+		"io/ioutil"
+		"os"
+		"os/exec"
+		"path/filepath"
+		"strconv"
+		"strings"
+		"time"
+)
+
+const (
+		AppStatusCommand = "app:status"
+		AppStopCommand   = "app:stop"
+		PidFileExt       = ".pid"
+		LockFileExt      = ".lock"
+		DaemonLogExt     = ".log"
+		DaemonFlag       = "--daemon"
+		// 后台子进程标记, 防止重复 fork
+		DaemonEnv = "KITS_DAEMON"
+)
+
+// 单实例锁
+type instanceLock struct {
+		pidFile string
+		file    *os.File
+}
+
+// PID 文件, 默认 BasePath/AppName.pid
+func (this *ApplicationImpl) pidFile() string {
+		if path, ok := this.GetProfile(Contracts.AppPidFile).(string); ok && path != "" {
+				return path
+		}
+		var (
+				base, _ = this.GetProfile(BasePath).(string)
+				name, _ = this.GetProfile("AppName").(string)
+		)
+		return filepath.Join(base, name+PidFileExt)
+}
+
+// 锁文件, 与 PID 文件同目录
+func lockFileOf(pidFile string) string {
+		return strings.TrimSuffix(pidFile, PidFileExt) + LockFileExt
+}
+
+// 独占锁定 lock 文件 并写入 PID 文件, 已有实例运行时 返回错误
+// 控制台命令 或者 WithSingleInstance(false) 时 不锁定
+func (this *ApplicationImpl) lockInstance() error {
+		if !this.singleInstance() {
+				return nil
+		}
+		if Components.RunningCommand(this) || this.instance != nil {
+				return nil
+		}
+		var pidFile = this.pidFile()
+		if err := os.MkdirAll(filepath.Dir(pidFile), 0755); err != nil {
+				return err
+		}
+		file, err := os.OpenFile(lockFileOf(pidFile), os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+				return err
+		}
+		if err = lockFile(file); err != nil {
+				_ = file.Close()
+				return runningError(pidFile, err)
+		}
+		if err = ioutil.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644); err != nil {
+				_ = unlockFile(file)
+				_ = file.Close()
+				return err
+		}
+		this.instance = &instanceLock{pidFile: pidFile, file: file}
+		return nil
+}
+
+// 是否单实例运行, WithSingleInstance(false) 时 否
+func (this *ApplicationImpl) singleInstance() bool {
+		enable, ok := this.GetProfile(Contracts.AppSingleInstance).(bool)
+		return !ok || enable
+}
+
+// 后台运行前 检查单实例锁, 已有实例运行时 不再 fork
+func (this *ApplicationImpl) checkInstance() error {
+		if !this.singleInstance() || this.instance != nil {
+				return nil
+		}
+		var pidFile = this.pidFile()
+		if instanceHeld(pidFile) {
+				return runningError(pidFile, nil)
+		}
+		return nil
+}
+
+// 已有实例运行
+func runningError(pidFile string, err error) error {
+		if pid, ok := readPid(pidFile); ok {
+				return fmt.Errorf("instance already running, pid %d (%s)", pid, pidFile)
+		}
+		return fmt.Errorf("instance already running, lock %s held: %v", lockFileOf(pidFile), err)
+}
+
+// 锁文件 是否被运行中的实例持有, 非阻塞尝试加锁 成功时 立即释放
+func instanceHeld(pidFile string) bool {
+		file, err := os.OpenFile(lockFileOf(pidFile), os.O_RDWR, 0644)
+		if err != nil {
+				return false
+		}
+		defer file.Close()
+		if err = lockFile(file); err != nil {
+				return true
+		}
+		_ = unlockFile(file)
+		return false
+}
+
+// 释放锁 并删除 PID 文件
+func (this *ApplicationImpl) unlockInstance() {
+		if this.instance == nil {
+				return
+		}
+		if pid, ok := readPid(this.instance.pidFile); ok && pid == os.Getpid() {
+				_ = os.Remove(this.instance.pidFile)
+		}
+		_ = unlockFile(this.instance.file)
+		_ = this.instance.file.Close()
+		this.instance = nil
+}
+
+// 读取 PID 文件
+func readPid(pidFile string) (int, bool) {
+		data, err := ioutil.ReadFile(pidFile)
+		if err != nil {
+				return 0, false
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		return pid, err == nil && pid > 0
+}
+
+// 是否请求后台运行 (--daemon 或者 WithDaemon), 后台子进程 不再 fork
+func (this *ApplicationImpl) daemonRequested() bool {
+		if os.Getenv(DaemonEnv) != "" {
+				return false
+		}
+		if daemon, ok := this.GetProfile(Contracts.AppDaemon).(bool); ok && daemon {
+				return true
+		}
+		for _, arg := range os.Args[1:] {
+				if arg == DaemonFlag {
+						return true
+				}
+		}
+		return false
+}
+
+// 以新会话 重新执行当前命令 (去掉 --daemon), 输出重定向到 BasePath/AppName.log
+func (this *ApplicationImpl) daemonize() (int, error) {
+		var args []string
+		for _, arg := range os.Args[1:] {
+				if arg != DaemonFlag {
+						args = append(args, arg)
+				}
+		}
+		logFile := strings.TrimSuffix(this.pidFile(), PidFileExt) + DaemonLogExt
+		out, err := os.OpenFile(logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+				return 0, err
+		}
+		defer out.Close()
+		cmd := exec.Command(os.Args[0], args...)
+		cmd.Env = append(os.Environ(), DaemonEnv+"=1")
+		cmd.Stdout, cmd.Stderr = out, out
+		if cmd.SysProcAttr, err = daemonAttr(); err != nil {
+				return 0, err
+		}
+		if err = cmd.Start(); err != nil {
+				return 0, err
+		}
+		var pid = cmd.Process.Pid
+		return pid, cmd.Process.Release()
+}
+
+// 运行中实例的 PID, PID 文件存在 且 锁文件被持有
+func runningPid(pidFile string) (int, bool) {
+		pid, ok := readPid(pidFile)
+		if !ok || !processAlive(pid) || !instanceHeld(pidFile) {
+				return 0, false
+		}
+		return pid, true
+}
+
+// app:status 运行中 返回 0, 否则 返回 1
+func (this *ApplicationImpl) appStatus(args []string, out io.Writer) int {
+		var pidFile = this.pidFile()
+		pid, ok := runningPid(pidFile)
+		if !ok {
+				fmt.Fprintf(out, "stopped (%s)\n", pidFile)
+				return 1
+		}
+		fmt.Fprintf(out, "running, pid %d (%s)\n", pid, pidFile)
+		return 0
+}
+
+// app:stop 通过 PID 文件 通知运行中的实例 优雅停止, 等待退出
+func (this *ApplicationImpl) appStop(args []string, out io.Writer) int {
+		var pidFile = this.pidFile()
+		pid, ok := runningPid(pidFile)
+		if !ok {
+				fmt.Fprintf(out, "not running (%s)\n", pidFile)
+				return 1
+		}
+		if err := terminateProcess(pid); err != nil {
+				fmt.Fprintf(out, "stop pid %d failed: %v\n", pid, err)
+				return 1
+		}
+		// 等待 优雅关闭 总时限 + 1s
+		for deadline := time.Now().Add(this.shutdownTimeout() + time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+				if !processAlive(pid) {
+						fmt.Fprintf(out, "stopped, pid %d\n", pid)
+						return 0
+				}
+		}
+		fmt.Fprintf(out, "pid %d still running after %s\n", pid, this.shutdownTimeout())
+		return 1
+}
@@ -0,0 +1,68 @@
+package Supports
+
+import (
+		"bytes"
+		. "github.com/smartystreets/goconvey/convey"
+		"io/ioutil"
+		"os"
+		"path/filepath"
+		"strconv"
+		"testing"
+)
+
+func TestApplicationInstanceLock(t *testing.T) {
+		dir, err := ioutil.TempDir("", "kits-instance")
+		if err != nil {
+				t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		var (
+				first   = NewApp(WithBasePath(dir), WithAppName("game"))
+				second  = NewApp(WithBasePath(dir), WithAppName("game"))
+				other   = NewApp(WithBasePath(dir), WithAppName("chat"))
+				custom  = NewApp(WithPidFile(filepath.Join(dir, "run", "custom.pid")))
+				skipped = NewApp(WithBasePath(dir), WithAppName("game"), WithSingleInstance(false))
+				pidFile = filepath.Join(dir, "game.pid")
+		)
+		Convey("Application Instance Lock Test", t, func() {
+				So(first.pidFile(), ShouldEqual, pidFile)
+				So(first.lockInstance(), ShouldBeNil)
+				pid, ok := readPid(pidFile)
+				So(ok, ShouldBeTrue)
+				So(pid, ShouldEqual, os.Getpid())
+
+				err := second.lockInstance()
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "already running, pid "+strconv.Itoa(os.Getpid()))
+				So(other.lockInstance(), ShouldBeNil)
+				So(custom.lockInstance(), ShouldBeNil)
+				So(skipped.lockInstance(), ShouldBeNil)
+				So(skipped.instance, ShouldBeNil)
+
+				var out bytes.Buffer
+				So(second.appStatus(nil, &out), ShouldEqual, 0)
+				So(out.String(), ShouldContainSubstring, "running, pid")
+				So(second.checkInstance(), ShouldNotBeNil)
+				So(skipped.checkInstance(), ShouldBeNil)
+
+				first.unlockInstance()
+				_, err = os.Stat(pidFile)
+				So(os.IsNotExist(err), ShouldBeTrue)
+				out.Reset()
+				So(second.appStatus(nil, &out), ShouldEqual, 1)
+				So(second.appStop(nil, &out), ShouldEqual, 1)
+				So(out.String(), ShouldContainSubstring, "not running")
+				So(second.checkInstance(), ShouldBeNil)
+				So(second.lockInstance(), ShouldBeNil)
+				second.unlockInstance()
+
+				// PID 文件残留 但锁未被持有, 不视为运行中 不发送信号
+				So(ioutil.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644), ShouldBeNil)
+				out.Reset()
+				So(second.appStatus(nil, &out), ShouldEqual, 1)
+				So(second.appStop(nil, &out), ShouldEqual, 1)
+				So(out.String(), ShouldContainSubstring, "not running")
+				other.unlockInstance()
+				custom.unlockInstance()
+		})
+}
@@ -0,0 +1,42 @@
+//go:build !windows
+// +build !windows
+
+package Supports
+
+import (
+		"os"
+		"syscall"
+)
+
+// 非阻塞 独占锁
+func lockFile(file *os.File) error {
+		return syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
+
+func unlockFile(file *os.File) error {
+		return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
+
+// 进程是否存在
+func processAlive(pid int) bool {
+		process, err := os.FindProcess(pid)
+		if err != nil {
+				return false
+		}
+		err = process.Signal(syscall.Signal(0))
+		return err == nil || err == syscall.EPERM
+}
+
+// 发送 SIGTERM
+func terminateProcess(pid int) error {
+		process, err := os.FindProcess(pid)
+		if err != nil {
+				return err
+		}
+		return process.Signal(syscall.SIGTERM)
+}
+
+// 脱离终端 新会话
+func daemonAttr() (*syscall.SysProcAttr, error) {
+		return &syscall.SysProcAttr{Setsid: true}, nil
+}
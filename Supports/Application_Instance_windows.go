@@ -0,0 +1,42 @@
+package Supports
+
+import (
+		"errors"
+		"os"
+		"syscall"
+)
+
+// windows 不支持 flock, 以独占创建 标记文件 代替
+func lockFile(file *os.File) error {
+		marker, err := os.OpenFile(file.Name()+".owner", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+				return err
+		}
+		return marker.Close()
+}
+
+func unlockFile(file *os.File) error {
+		return os.Remove(file.Name() + ".owner")
+}
+
+func processAlive(pid int) bool {
+		process, err := os.FindProcess(pid)
+		if err != nil {
+				return false
+		}
+		_ = process.Release()
+		return true
+}
+
+// windows 无 SIGTERM, 直接结束进程
+func terminateProcess(pid int) error {
+		process, err := os.FindProcess(pid)
+		if err != nil {
+				return err
+		}
+		return process.Kill()
+}
+
+func daemonAttr() (*syscall.SysProcAttr, error) {
+		return nil, errors.New("daemon mode is not supported on windows")
+}
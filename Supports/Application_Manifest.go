@@ -0,0 +1,189 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs/Errors"
+		"strings"
+)
+
+const (
+		// 服务提供器清单, eg: app.providers=IrisHttpServer,DatabaseHealthProvider
+		// 配置文件的键 以文件名为前缀, config/app.properties 中写作 providers=IrisHttpServer
+		// 同名应用属性 ([]string) 为默认清单, 配置清单 在其后叠加
+		ProvidersManifestKey = "app.providers"
+		// 运行模式覆盖, eg: app.prod.providers=RedisProvider,-DatabaseHealthProvider
+		// config/app.properties 中写作 prod.providers=-DatabaseHealthProvider
+		ProvidersModeManifestKey = "app.%s.providers"
+		// 禁用前缀
+		providerDisablePrefix = "-"
+)
+
+// 服务提供器清单
+type providerManifest struct {
+		enabled  []string
+		disabled map[string]bool
+		// 名称 => 配置键
+		keys map[string]string
+}
+
+// 注册核心服务提供器, 自定义服务提供器 保留到配置清单加载之后 (registerCustom)
+func (this *ApplicationImpl) registerCore() {
+		var custom []Contracts.RegisterInterface
+		this.registers.Foreach(func(key, value interface{}) bool {
+				if it := value.(Contracts.RegisterInterface); this.isCoreProvider(ProviderName(it)) {
+						this.reg(it)
+				} else {
+						custom = append(custom, it)
+				}
+				return true
+		})
+		this.registers = RegisterUniqueArrayOf(custom...)
+}
+
+// 引导核心服务提供器, 自定义服务提供器 保留到单实例锁之后 (LoadCustomProviders)
+func (this *ApplicationImpl) bootCore() {
+		var custom []Contracts.BootInterface
+		this.boots.Foreach(func(key, value interface{}) bool {
+				if it := value.(Contracts.BootInterface); this.isCoreProvider(ProviderName(it)) {
+						this.boot(it)
+				} else {
+						custom = append(custom, it)
+				}
+				return true
+		})
+		this.boots = BooterUniqueArrayOf(custom...)
+}
+
+// 注册自定义服务提供器 (代码注册 和 配置清单加载的)
+func (this *ApplicationImpl) registerCustom() error {
+		if err := this.sortRegisters(); err != nil {
+				return err
+		}
+		this.registers.Foreach(this.foreachRegister())
+		return nil
+}
+
+// 按配置清单 加载/禁用 服务提供器
+// 名称在 Components.RegisterProvider 注册表中查找, 未注册的名称 记录错误日志 并跳过
+func (this *ApplicationImpl) loadManifest() {
+		var manifest = this.manifest()
+		for _, name := range manifest.enabled {
+				if manifest.disabled[name] || this.hasProvider(name) {
+						continue
+				}
+				factory := Components.ProviderFactoryOf(name)
+				if factory == nil {
+						this.logger().Error(Errors.UnresolvableError(fmt.Sprintf("%s: provider %s not registered", manifest.keys[name], name)).Error())
+						continue
+				}
+				this.register(factory())
+		}
+		for name := range manifest.disabled {
+				this.disableProvider(name)
+		}
+}
+
+// 读取清单 (默认清单 之后 叠加 app.providers, 当前运行模式 app.<mode>.providers)
+// 核心服务提供器 引导前读取, 先执行配置加载器
+func (this *ApplicationImpl) manifest() *providerManifest {
+		var manifest = &providerManifest{disabled: make(map[string]bool), keys: make(map[string]string)}
+		if names, ok := this.GetProfile(ProvidersManifestKey).([]string); ok {
+				manifest.add(ProvidersManifestKey, names)
+		}
+		config, ok := this.Get(Components.ConfigureProviderClass).(Components.ConfigureProvider)
+		if !ok {
+				return manifest
+		}
+		if loader, ok := config.(Contracts.ReloadInterface); ok {
+				loader.Reload()
+		}
+		var keys = []string{ProvidersManifestKey}
+		if mode, ok := this.GetProfile(RunModeEnv).(string); ok && mode != "" {
+				keys = append(keys, fmt.Sprintf(ProvidersModeManifestKey, mode))
+		}
+		for _, key := range keys {
+				manifest.add(key, config.Strings(key))
+		}
+		return manifest
+}
+
+// 叠加清单项, "-" 前缀 禁用
+func (this *providerManifest) add(key string, names []string) {
+		for _, name := range names {
+				name = strings.TrimSpace(name)
+				if name == "" {
+						continue
+				}
+				if strings.HasPrefix(name, providerDisablePrefix) {
+						this.disabled[strings.TrimPrefix(name, providerDisablePrefix)] = true
+						continue
+				}
+				delete(this.disabled, name)
+				this.enabled = append(this.enabled, name)
+				this.keys[name] = key
+		}
+}
+
+// 是否已注册服务提供器
+func (this *ApplicationImpl) hasProvider(name string) bool {
+		for key := range this.providers {
+				if strings.EqualFold(key, name) {
+						return true
+				}
+		}
+		return false
+}
+
+// 是否核心服务提供器
+func (this *ApplicationImpl) isCoreProvider(name string) bool {
+		for _, provider := range this.getCoreProviders() {
+				if strings.EqualFold(ProviderName(provider), name) {
+						return true
+				}
+		}
+		return false
+}
+
+// 禁用未加载的自定义服务提供器, 核心服务提供器 不可禁用
+func (this *ApplicationImpl) disableProvider(name string) {
+		if this.isCoreProvider(name) {
+				panic(Errors.DependencyError("core provider " + name + " can not be disabled"))
+		}
+		var matched = func(v interface{}) bool {
+				return strings.EqualFold(ProviderName(v), name)
+		}
+		var registers []Contracts.RegisterInterface
+		this.registers.Foreach(func(key, value interface{}) bool {
+				if !matched(value) {
+						registers = append(registers, value.(Contracts.RegisterInterface))
+				}
+				return true
+		})
+		this.registers = RegisterUniqueArrayOf(registers...)
+		var boots []Contracts.BootInterface
+		this.boots.Foreach(func(key, value interface{}) bool {
+				if !matched(value) {
+						boots = append(boots, value.(Contracts.BootInterface))
+				}
+				return true
+		})
+		this.boots = BooterUniqueArrayOf(boots...)
+		this.deferredMutex.Lock()
+		for id, loader := range this.deferred {
+				if !loader.started && matched(loader.provider) {
+						delete(this.deferred, id)
+				}
+		}
+		this.deferredMutex.Unlock()
+		var names []string
+		for _, it := range this.providerNames {
+				if strings.EqualFold(it, name) {
+						delete(this.providers, it)
+						continue
+				}
+				names = append(names, it)
+		}
+		this.providerNames = names
+}
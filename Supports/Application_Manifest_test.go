@@ -0,0 +1,116 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"io/ioutil"
+		"os"
+		"path/filepath"
+		"strings"
+		"sync/atomic"
+		"testing"
+)
+
+type manifestProvider struct {
+		Components.AppServiceProvider
+		registers int32
+}
+
+func (this *manifestProvider) Register() {
+		atomic.AddInt32(&this.registers, 1)
+}
+
+func newManifestProvider(name string) *manifestProvider {
+		return &manifestProvider{AppServiceProvider: Components.AppServiceProvider{Name: name}}
+}
+
+func TestApplicationManifest(t *testing.T) {
+		var created = make(map[string]*manifestProvider)
+		for _, name := range []string{"ManifestRedis", "ManifestMysql", "ManifestNats"} {
+				name := name
+				Components.RegisterProvider(name, func() Contracts.Provider {
+						created[name] = newManifestProvider(name)
+						return created[name]
+				})
+		}
+		var (
+				config = "app.providers=ManifestRedis, ManifestMysql\napp.prod.providers=ManifestNats,-ManifestMysql,-ManifestCustom\n"
+				app    = NewApp(WithRunMode(RunModeProd), WithConfigReader(strings.NewReader(config)))
+				custom = newManifestProvider("ManifestCustom")
+		)
+		app.Register(custom)
+		app.providersInit()
+		Convey("Application Manifest Test", t, func() {
+				So(app.hasProvider("ManifestRedis"), ShouldBeTrue)
+				So(app.hasProvider("ManifestNats"), ShouldBeTrue)
+				So(app.hasProvider("ManifestMysql"), ShouldBeFalse)
+				So(app.hasProvider("ManifestCustom"), ShouldBeFalse)
+				So(created["ManifestMysql"], ShouldBeNil)
+				So(atomic.LoadInt32(&created["ManifestRedis"].registers), ShouldEqual, 1)
+				So(atomic.LoadInt32(&created["ManifestNats"].registers), ShouldEqual, 1)
+				So(atomic.LoadInt32(&custom.registers), ShouldEqual, 0)
+				So(app.providerNames, ShouldNotContain, "ManifestCustom")
+		})
+		Convey("Application Manifest Unknown Provider Test", t, func() {
+				var app = NewApp(WithConfigReader(strings.NewReader("app.providers=ManifestUnknown,ManifestRedis\n")))
+				So(app.LoadCoreProviders(), ShouldBeNil)
+				So(app.hasProvider("ManifestUnknown"), ShouldBeFalse)
+				So(app.hasProvider("ManifestRedis"), ShouldBeTrue)
+		})
+		Convey("Application Manifest File Test", t, func() {
+				// 文件中的键 以文件名为前缀, app.properties 的 providers 即 app.providers
+				dir, err := ioutil.TempDir("", "kits-manifest")
+				So(err, ShouldBeNil)
+				defer os.RemoveAll(dir)
+				var file = filepath.Join(dir, "app.properties")
+				So(ioutil.WriteFile(file, []byte("providers=ManifestNats\n"), 0644), ShouldBeNil)
+				var app = NewApp(WithConfigFiles(file))
+				app.LoadCoreProviders()
+				So(app.hasProvider("ManifestNats"), ShouldBeTrue)
+		})
+		Convey("Application Manifest Default Test", t, func() {
+				// 默认清单 之后 叠加配置清单, 配置可禁用默认项
+				var app = NewApp(WithManifest("ManifestRedis", "ManifestMysql"), WithConfigReader(strings.NewReader("app.providers=ManifestNats,-ManifestMysql\n")))
+				app.LoadCoreProviders()
+				So(app.hasProvider("ManifestRedis"), ShouldBeTrue)
+				So(app.hasProvider("ManifestNats"), ShouldBeTrue)
+				So(app.hasProvider("ManifestMysql"), ShouldBeFalse)
+		})
+		Convey("Application Manifest Core Provider Test", t, func() {
+				var app = NewApp(WithConfigReader(strings.NewReader("app.providers=-" + Components.LoggerProviderClass + "\n")))
+				So(func() {
+						_ = app.LoadCoreProviders()
+				}, ShouldPanic)
+		})
+}
+
+func TestApplicationLifecycleOrder(t *testing.T) {
+		var (
+				app    = NewApp(WithConfigReader(strings.NewReader("app.providers=ManifestOrder\n")))
+				custom = newManifestProvider("ManifestCustom")
+				loaded *manifestProvider
+				order  []string
+		)
+		Components.RegisterProvider("ManifestOrder", func() Contracts.Provider {
+				loaded = newManifestProvider("ManifestOrder")
+				return loaded
+		})
+		app.Register(custom)
+		app.properties.Store(ctrlChan, make(chan int, 2))
+		// 核心服务引导前 自定义服务 (含清单加载的) 已注册
+		app.Booting(func(Contracts.ApplicationContainer) {
+				order = append(order, "booting")
+				if atomic.LoadInt32(&custom.registers) == 1 && loaded != nil && atomic.LoadInt32(&loaded.registers) == 1 {
+						order = append(order, "custom registered")
+				}
+		})
+		app.Booted(func(Contracts.ApplicationContainer) {
+				order = append(order, "booted")
+		})
+		app.providersInit()
+		app.Stop()
+		Convey("Application Lifecycle Order Test", t, func() {
+				So(order, ShouldResemble, []string{"booting", "custom registered", "booted"})
+		})
+}
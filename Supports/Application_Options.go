@@ -0,0 +1,122 @@
+package Supports
+
+import (
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"io"
+		"sync"
+)
+
+// 应用选项, NewApp 时使用
+type AppOption func(app *ApplicationImpl)
+
+// 应用属性, 替换默认属性
+func WithProps(props *ApplicationProps) AppOption {
+		return func(app *ApplicationImpl) {
+				if props == nil {
+						return
+				}
+				app.props = props
+				app.properties.Store(defaultPropsKey, props)
+		}
+}
+
+// 替换核心服务提供器
+func WithProviders(providers ...Contracts.Provider) AppOption {
+		return func(app *ApplicationImpl) {
+				app.props.Providers = providers
+		}
+}
+
+func WithAppName(name string) AppOption {
+		return func(app *ApplicationImpl) {
+				app.props.AppName = name
+		}
+}
+
+// 项目目录, 配置目录 默认为 path/configs
+func WithBasePath(path string) AppOption {
+		return func(app *ApplicationImpl) {
+				app.props.BasePath = path
+				app.props.ConfigDir = app.props.getCurrentConfigDir()
+		}
+}
+
+func WithConfigDir(dir string) AppOption {
+		return func(app *ApplicationImpl) {
+				app.props.ConfigDir = dir
+		}
+}
+
+// 运行环境 dev,test,local,prod,stg
+func WithRunMode(mode string) AppOption {
+		return func(app *ApplicationImpl) {
+				if IsSupportMode(mode) {
+						app.props.RunMode = mode
+				}
+		}
+}
+
+// 配置文件
+func WithConfigFiles(files ...string) AppOption {
+		return WithProfile(Contracts.AppPropertiesFiles, files)
+}
+
+// 配置目录
+func WithConfigPaths(paths ...string) AppOption {
+		return WithProfile(Contracts.AppPropertiesPaths, paths)
+}
+
+func WithConfigReader(reader io.Reader) AppOption {
+		return WithProfile(Contracts.AppPropertiesReader, reader)
+}
+
+// 应用内环境变量, 不修改进程环境变量, 优先于 env 文件
+func WithEnv(values map[string]string) AppOption {
+		return func(app *ApplicationImpl) {
+				var presets = make(map[string]string)
+				if v, ok := app.properties.Load(Contracts.AppEnvPresets); ok {
+						for key, value := range v.(map[string]string) {
+								presets[key] = value
+						}
+				}
+				for key, value := range values {
+						presets[key] = value
+				}
+				app.properties.Store(Contracts.AppEnvPresets, presets)
+		}
+}
+
+// 默认服务提供器清单, 按名称 由 Components.RegisterProvider 注册表创建, 配置 app.providers 在其后叠加
+func WithManifest(names ...string) AppOption {
+		return WithProfile(ProvidersManifestKey, names)
+}
+
+// 应用属性
+func WithProfile(key string, value interface{}) AppOption {
+		return func(app *ApplicationImpl) {
+				app.PropertyLoader(func(p *sync.Map) {
+						p.Store(key, value)
+				})
+		}
+}
+
+// 命令名前的全局选项 查找, 默认为应用的全局选项 (OptionSpecs)
+func WithCommandOptions(lookup Components.OptionLookup) AppOption {
+		return WithProfile(Contracts.AppCommandOptions, lookup)
+}
+
+// PID 文件, 锁文件 同目录 同名 .lock; 默认 BasePath/AppName.pid
+func WithPidFile(path string) AppOption {
+		return WithProfile(Contracts.AppPidFile, path)
+}
+
+// 单实例锁, 默认开启
+func WithSingleInstance(enable bool) AppOption {
+		return WithProfile(Contracts.AppSingleInstance, enable)
+}
+
+// 后台运行, 等同 --daemon
+func WithDaemon() AppOption {
+		return WithProfile(Contracts.AppDaemon, true)
+}
@@ -0,0 +1,88 @@
+package Supports
+
+import (
+		"fmt"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"io/ioutil"
+		"os"
+		"path/filepath"
+		"strings"
+		"sync"
+		"testing"
+)
+
+func TestNewAppIsolated(t *testing.T) {
+		var (
+				apps = make([]*ApplicationImpl, 2)
+				wg   sync.WaitGroup
+		)
+		for i := range apps {
+				dir, err := ioutil.TempDir("", "kits-app")
+				if err != nil {
+						t.Fatal(err)
+				}
+				defer os.RemoveAll(dir)
+				env := fmt.Sprintf("redis_addr=file\nnode_id=node-%d\n", i)
+				if err := ioutil.WriteFile(filepath.Join(dir, RunModeTest+".env"), []byte(env), 0644); err != nil {
+						t.Fatal(err)
+				}
+				apps[i] = NewApp(
+						WithAppName(fmt.Sprintf("game-%d", i)),
+						WithBasePath(dir),
+						WithRunMode(RunModeTest),
+						WithEnv(map[string]string{"redis_addr": fmt.Sprintf("127.0.0.1:%d", 6379+i)}),
+						WithConfigReader(strings.NewReader(fmt.Sprintf("http.port=%d\n", 9090+i))),
+				)
+		}
+		for _, app := range apps {
+				wg.Add(1)
+				go func(app *ApplicationImpl) {
+						defer wg.Done()
+						app.LoadCoreProviders()
+				}(app)
+		}
+		wg.Wait()
+		Convey("New App Isolated Test", t, func() {
+				for i, app := range apps {
+						env := app.Get(Components.EnvironmentProviderClass).(Components.EnvironmentProvider)
+						config := app.Get(Components.ConfigureProviderClass).(Components.ConfigureProvider)
+						So(app.GetProfile("AppName"), ShouldEqual, fmt.Sprintf("game-%d", i))
+						So(env.Get("redis_addr"), ShouldEqual, fmt.Sprintf("127.0.0.1:%d", 6379+i))
+						So(env.Get("node_id"), ShouldEqual, fmt.Sprintf("node-%d", i))
+						So(config.Get("http.port"), ShouldEqual, fmt.Sprintf("%d", 9090+i))
+						So(env, ShouldNotEqual, Components.EnvironmentProviderOf())
+				}
+				So(apps[0].Get(Components.LoggerProviderClass), ShouldNotEqual, apps[1].Get(Components.LoggerProviderClass))
+				So(apps[0].Get(Components.EventBusProviderClass), ShouldNotEqual, apps[1].Get(Components.EventBusProviderClass))
+				So(apps[0].GetProfile(ctrlChan), ShouldNotEqual, apps[1].GetProfile(ctrlChan))
+				So(os.Getenv("redis_addr"), ShouldEqual, "")
+		})
+}
+
+func TestNewAppCommandOptions(t *testing.T) {
+		var args = os.Args
+		defer func() {
+				os.Args = args
+		}()
+		dir, err := ioutil.TempDir("", "kits-app")
+		if err != nil {
+				t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		os.Args = []string{"kits", "--mode", RunModeTest, ContainerListCommand}
+		var (
+				app    = NewApp(WithBasePath(dir))
+				custom = NewApp(WithBasePath(dir), WithCommandOptions(func(flag string) (bool, bool) {
+						return false, false
+				}))
+		)
+		app.LoadCoreProviders()
+		custom.LoadCoreProviders()
+		Convey("New App Command Options Test", t, func() {
+				name, _ := app.Get(Components.CommandLineProviderClass).(Components.Commander).Called()
+				So(name, ShouldEqual, ContainerListCommand)
+				name, _ = custom.Get(Components.CommandLineProviderClass).(Components.Commander).Called()
+				So(name, ShouldEqual, "")
+		})
+}
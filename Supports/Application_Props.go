@@ -6,6 +6,7 @@ import (
 		"os"
 		"path/filepath"
 		"reflect"
+		"time"
 )
 
 type ApplicationProps struct {
@@ -13,11 +14,15 @@ type ApplicationProps struct {
 		ConfigFilesSuffix []string
 		AppName           string
 		Version           string
+		GitCommit         string
+		BuildTime         string
+		GoVersion         string
 		ApkPath           string
 		BasePath          string
 		ConfigDir         string
 		RunMode           string
 		CtrChan           chan int
+		ShutdownTimeout   time.Duration
 }
 
 // 常量
@@ -62,6 +67,22 @@ func defaultPropsFactory() {
 		defaultProps.init()
 }
 
+// 独立的应用属性, 核心服务提供器 非全局
+func newApplicationProps() *ApplicationProps {
+		var props = new(ApplicationProps)
+		props.initKeyValues()
+		props.Providers = []Contracts.Provider{
+				Components.NewAppBootstrapper(),
+				Components.NewCommandLineArgsProvider(),
+				Components.NewEnvironmentProvider(),
+				Components.NewConfigureProvider(),
+				Components.NewLoggerProvider(),
+				Components.NewEventBusProvider(),
+				Components.NewHealthRegistryProvider(),
+		}
+		return props
+}
+
 func GetSupportRunModes() []string {
 		return supportRunModes
 }
@@ -82,13 +103,30 @@ func (this *ApplicationProps) GetProviders() []Contracts.Provider {
 
 func (this *ApplicationProps) initKeyValues() {
 		this.AppName = appName
-		this.Version = appVersionName
+		this.initBuildInfo()
 		this.CtrChan = make(chan int, 2)
 		this.ApkPath = reflect.TypeOf(this).Elem().PkgPath()
 		this.ConfigFilesSuffix = []string{".yml", ".properties", ".ini"}
 		this.BasePath = this.getCurrentDir()
 		this.RunMode = this.getCurrentMode()
 		this.ConfigDir = this.getCurrentConfigDir()
+		this.ShutdownTimeout = this.getShutdownTimeout()
+}
+
+// 构建信息, -ldflags 注入
+func (this *ApplicationProps) initBuildInfo() {
+		var info = GetBuildInfo()
+		this.Version = info.Version
+		this.GitCommit = info.GitCommit
+		this.BuildTime = info.BuildTime
+		this.GoVersion = info.GoVersion
+}
+
+func (this *ApplicationProps) getShutdownTimeout() time.Duration {
+		if d, err := time.ParseDuration(os.Getenv(ShutdownTimeoutEnv)); err == nil && d > 0 {
+				return d
+		}
+		return ShutdownTimeoutDefault
 }
 
 func (this *ApplicationProps) getCurrentDir() string {
@@ -119,6 +157,8 @@ func (this *ApplicationProps) initProviders() {
 				Components.EnvironmentProviderOf(),     // environment
 				Components.ConfigureProviderOf(),       // configure
 				Components.LoggerProviderOf(),          // logger
+				Components.EventBusProviderOf(),        // events
+				Components.HealthRegistryProviderOf(),  // health
 		}
 }
 
@@ -136,8 +176,27 @@ func (this *ApplicationProps) Get(key string) interface{} {
 		case "app_name":
 				return this.AppName
 		case "Version":
+				fallthrough
 		case "version":
 				return this.Version
+		case "GitCommit":
+				fallthrough
+		case "gitcommit":
+				fallthrough
+		case "git_commit":
+				return this.GitCommit
+		case "BuildTime":
+				fallthrough
+		case "buildtime":
+				fallthrough
+		case "build_time":
+				return this.BuildTime
+		case "GoVersion":
+				fallthrough
+		case "goversion":
+				fallthrough
+		case "go_version":
+				return this.GoVersion
 		case "ApkPath":
 				fallthrough
 		case "apk_path":
@@ -174,6 +233,12 @@ func (this *ApplicationProps) Get(key string) interface{} {
 				fallthrough
 		case "run_mode":
 				return this.RunMode
+		case "ShutdownTimeout":
+				fallthrough
+		case "shutdowntimeout":
+				fallthrough
+		case "shutdown_timeout":
+				return this.ShutdownTimeout
 		}
 		return nil
 }
@@ -191,7 +256,9 @@ func (this *ApplicationProps) Foreach(each func(key string, value interface{}) b
 func (this *ApplicationProps) keys() []string {
 		return []string{
 				"Providers", "AppName", "Version",
+				"GitCommit", "BuildTime", "GoVersion",
 				"ApkPath", "ConfigFilesSuffix", "appCtrlChan",
 				"BasePath", "ConfigDir", "RunMode",
+				"ShutdownTimeout",
 		}
 }
@@ -0,0 +1,276 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs/Errors"
+		"reflect"
+		"sync"
+)
+
+// 作用域容器, 本地绑定和作用域实例 优先, 其余回退到父容器
+type scopeContainer struct {
+		Contracts.ApplicationContainer
+		parent    containerResolver
+		binds     map[string]interface{}
+		aliases   map[string]string
+		factories map[string]func(Contracts.ApplicationContainer) interface{}
+		instances map[string]*entry
+		resolved  []teardownItem
+		mutex     sync.Mutex
+		ended     bool
+}
+
+func newScope(parent containerResolver) *scopeContainer {
+		var scope = new(scopeContainer)
+		scope.ApplicationContainer = parent
+		scope.parent = parent
+		scope.binds = make(map[string]interface{})
+		scope.aliases = make(map[string]string)
+		scope.factories = make(map[string]func(Contracts.ApplicationContainer) interface{})
+		scope.instances = make(map[string]*entry)
+		return scope
+}
+
+// 注册作用域服务, 每个作用域 首次解析时 创建一个实例, 作用域结束时销毁
+func (this *ApplicationImpl) Scoped(id string, factory func(Contracts.ApplicationContainer) interface{}) {
+		if id == "" || factory == nil {
+				return
+		}
+		if this.container.Exists(id) {
+				return
+		}
+		this.container.Scoped(id, factory)
+}
+
+// 创建作用域
+func (this *ApplicationImpl) NewScope() Contracts.ScopeContainer {
+		return newScope(this)
+}
+
+func (this *ApplicationImpl) scopedFactory(id string) func(Contracts.ApplicationContainer) interface{} {
+		entry := this.container.Resolver(id)
+		if entry == nil || !entry.Extras().Bool(SCOPED) {
+				return nil
+		}
+		return InstanceOfFactory(entry.Value())
+}
+
+func (this *scopeContainer) Get(id string) interface{} {
+		obj, _ := this.Resolve(id)
+		return obj
+}
+
+func (this *scopeContainer) Resolve(id string) (interface{}, error) {
+		return this.resolveIn(id, nil)
+}
+
+func (this *scopeContainer) resolveIn(id string, stack []string) (interface{}, error) {
+		this.mutex.Lock()
+		if clazz, ok := this.aliases[id]; ok {
+				id = clazz
+		}
+		if obj, ok := this.binds[id]; ok {
+				this.mutex.Unlock()
+				if len(stack) > 0 {
+						this.trace(stack, id, BIND)
+				}
+				return obj, nil
+		}
+		this.mutex.Unlock()
+		if factory := this.scopedFactory(id); factory != nil {
+				return this.resolve(id, factory, stack)
+		}
+		return this.parent.resolveIn(id, stack)
+}
+
+// 本地绑定, 覆盖父容器同名服务
+func (this *scopeContainer) Bind(id string, object interface{}) {
+		object = this.extend(id, object, this)
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		this.binds[id] = object
+}
+
+func (this *scopeContainer) Alias(clazz string, alias string) {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		this.aliases[alias] = clazz
+}
+
+// 作用域内 单例 等同 Scoped
+func (this *scopeContainer) Singleton(id string, factory func(Contracts.ApplicationContainer) interface{}) {
+		this.Scoped(id, factory)
+}
+
+func (this *scopeContainer) Scoped(id string, factory func(Contracts.ApplicationContainer) interface{}) {
+		if id == "" || factory == nil {
+				return
+		}
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		this.factories[id] = factory
+}
+
+func (this *scopeContainer) Exists(id string) bool {
+		this.mutex.Lock()
+		_, bind := this.binds[id]
+		_, alias := this.aliases[id]
+		this.mutex.Unlock()
+		return bind || alias || this.scopedFactory(id) != nil || this.parent.Exists(id)
+}
+
+func (this *scopeContainer) Fill(obj interface{}) error {
+		return fill(this, obj)
+}
+
+func (this *scopeContainer) Make(target interface{}, ids ...string) error {
+		return makeTarget(this, target, ids...)
+}
+
+// 标签服务 在作用域内解析
+func (this *scopeContainer) Tagged(tag string) []interface{} {
+		return tagged(this, tag)
+}
+
+func (this *scopeContainer) taggedIds(tag string) []string {
+		return this.parent.taggedIds(tag)
+}
+
+func (this *scopeContainer) contextual(consumer string, id string, container Contracts.ApplicationContainer) (interface{}, bool) {
+		return this.parent.contextual(consumer, id, container)
+}
+
+func (this *scopeContainer) extend(id string, obj interface{}, container Contracts.ApplicationContainer) interface{} {
+		return this.parent.extend(id, obj, container)
+}
+
+func (this *scopeContainer) hasContextual(consumer string) bool {
+		return this.parent.hasContextual(consumer)
+}
+
+// 嵌套作用域
+func (this *scopeContainer) NewScope() Contracts.ScopeContainer {
+		return newScope(this)
+}
+
+// 结束作用域, 按创建逆序 销毁作用域实例
+func (this *scopeContainer) End() {
+		this.mutex.Lock()
+		if this.ended {
+				this.mutex.Unlock()
+				return
+		}
+		var items = this.resolved
+		this.ended = true
+		this.resolved = nil
+		this.binds = make(map[string]interface{})
+		this.instances = make(map[string]*entry)
+		this.mutex.Unlock()
+		for i := len(items) - 1; i >= 0; i-- {
+				this.destroy(items[i])
+		}
+}
+
+// 本地定义优先, 其次外层作用域, 最后应用
+func (this *scopeContainer) scopedFactory(id string) func(Contracts.ApplicationContainer) interface{} {
+		this.mutex.Lock()
+		factory, ok := this.factories[id]
+		this.mutex.Unlock()
+		if ok {
+				return factory
+		}
+		return this.parent.scopedFactory(id)
+}
+
+// 按类型 优先匹配 本地绑定和已创建的作用域实例
+func (this *scopeContainer) resolveFor(id string, typ reflect.Type) (interface{}, bool) {
+		if id != "" {
+				obj := this.Get(id)
+				return obj, obj != nil
+		}
+		var injector = Components.NewInjector(Components.InjectTag)
+		this.mutex.Lock()
+		var objects = make([]interface{}, 0, len(this.binds)+len(this.instances))
+		for _, obj := range this.binds {
+				objects = append(objects, obj)
+		}
+		for _, en := range this.instances {
+				if obj, ok := en.Extras().Load(SINGLETON_OBJECT); ok {
+						objects = append(objects, obj)
+				}
+		}
+		this.mutex.Unlock()
+		for _, obj := range objects {
+				if injector.Assignable(obj, typ) {
+						return obj, true
+				}
+		}
+		return this.parent.resolveFor(id, typ)
+}
+
+// 创建作用域实例, 每个作用域 只构造一次
+func (this *scopeContainer) resolve(id string, factory func(Contracts.ApplicationContainer) interface{}, stack []string) (interface{}, error) {
+		if len(stack) > 0 {
+				this.trace(stack, id, SCOPED)
+		}
+		if err := circular(stack, id); err != nil {
+				return nil, err
+		}
+		this.mutex.Lock()
+		if this.ended {
+				this.mutex.Unlock()
+				return nil, Errors.ResolutionError(id + " resolved after scope end")
+		}
+		en, ok := this.instances[id]
+		if !ok {
+				en = EntryOf(id, factory)
+				this.instances[id] = en
+		}
+		this.mutex.Unlock()
+		return en.Singleton(func() (interface{}, error) {
+				var container = resolving(this, stack, id)
+				this.trace(nil, id, SCOPED)
+				instance, err := build(id, func() interface{} {
+						return factory(withContext(container, id))
+				})
+				if err == nil {
+						err = container.failed()
+				}
+				if err != nil || instance == nil {
+						return nil, err
+				}
+				instance = this.extend(id, instance, container)
+				this.mutex.Lock()
+				if this.ended {
+						this.mutex.Unlock()
+						this.destroy(teardownItem{name: id, object: instance})
+						return nil, Errors.ResolutionError(id + " resolved after scope end")
+				}
+				this.resolved = append(this.resolved, teardownItem{name: id, object: instance})
+				this.mutex.Unlock()
+				autowire(container, id, instance)
+				this.afterResolved(id, instance, container)
+				return instance, nil
+		})
+}
+
+func (this *scopeContainer) destroy(it teardownItem) {
+		if !isDestroyable(it.object) {
+				return
+		}
+		defer func() {
+				if err := recover(); err != nil {
+						this.logger().Error(fmt.Sprintf("scope destroy %s panic: %v", it.name, err))
+				}
+		}()
+		destroy(it.object)
+}
+
+func (this *scopeContainer) logger() Components.Logger {
+		if logger, ok := this.parent.Get(Components.LoggerAlias).(Components.Logger); ok {
+				return logger
+		}
+		return stdLogger{}
+}
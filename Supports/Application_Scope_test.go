@@ -0,0 +1,69 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"testing"
+)
+
+type requestUser struct {
+		name     string
+		recorder *closeRecorder
+}
+
+type userService struct {
+		User *requestUser `inject:"user"`
+		Id   string       `inject:"request.id"`
+}
+
+func (this *requestUser) Close() error {
+		this.recorder.add(this.name)
+		return nil
+}
+
+func TestApplicationScope(t *testing.T) {
+		var (
+				app      = newTestApp()
+				recorder = new(closeRecorder)
+		)
+		app.Bind("config", "global")
+		app.Scoped("user", func(scope Contracts.ApplicationContainer) interface{} {
+				id, _ := scope.Get("request.id").(string)
+				return &requestUser{name: "user-" + id, recorder: recorder}
+		})
+		app.Scoped("tx", func(scope Contracts.ApplicationContainer) interface{} {
+				return &requestUser{name: "tx", recorder: recorder}
+		})
+		Convey("Application Scope Test", t, func() {
+				So(app.Get("user"), ShouldBeNil)
+				So(app.Exists("user"), ShouldBeTrue)
+
+				first, second := app.NewScope(), app.NewScope()
+				first.Bind("request.id", "1")
+				second.Bind("request.id", "2")
+				user := first.Get("user").(*requestUser)
+				So(user.name, ShouldEqual, "user-1")
+				So(first.Get("user"), ShouldEqual, user)
+				So(second.Get("user").(*requestUser).name, ShouldEqual, "user-2")
+				So(first.Get("config"), ShouldEqual, "global")
+				So(app.Get("request.id"), ShouldBeNil)
+
+				service := new(userService)
+				So(first.Fill(service), ShouldBeNil)
+				So(service.User, ShouldEqual, user)
+				So(service.Id, ShouldEqual, "1")
+
+				nested := first.NewScope()
+				So(nested.Get("request.id"), ShouldEqual, "1")
+				So(nested.Get("user"), ShouldNotEqual, user)
+				nested.End()
+				So(recorder.String(), ShouldEqual, "user-1")
+
+				first.Get("tx")
+				first.End()
+				first.End()
+				So(recorder.String(), ShouldEqual, "user-1,tx,user-1")
+				second.End()
+				So(recorder.String(), ShouldEqual, "user-1,tx,user-1,user-2")
+		})
+}
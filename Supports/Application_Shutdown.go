@@ -0,0 +1,154 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"log"
+		"time"
+)
+
+// 待销毁组件
+type teardownItem struct {
+		name   string
+		object interface{}
+}
+
+const (
+		ShutdownTimeoutKey     = "ShutdownTimeout"
+		ShutdownTimeoutEnv     = "shutdown_timeout"
+		ShutdownTimeoutDefault = 10 * time.Second
+)
+
+// 记录已引导的服务提供器
+func (this *ApplicationImpl) addBooted(impl interface{}) {
+		this.stateMutex.Lock()
+		defer this.stateMutex.Unlock()
+		this.booted = append(this.booted, teardownItem{name: ProviderName(impl), object: impl})
+}
+
+// 记录已实例化的单例
+func (this *ApplicationImpl) addResolved(id string, instance interface{}) {
+		this.stateMutex.Lock()
+		defer this.stateMutex.Unlock()
+		this.resolved = append(this.resolved, teardownItem{name: id, object: instance})
+}
+
+// 销毁顺序: 服务提供器按引导逆序, 然后单例按实例化逆序
+func (this *ApplicationImpl) teardownItems() []teardownItem {
+		var (
+				items  []teardownItem
+				exists = make(map[interface{}]bool)
+		)
+		this.stateMutex.Lock()
+		defer this.stateMutex.Unlock()
+		for _, group := range [][]teardownItem{this.booted, this.resolved} {
+				for i := len(group) - 1; i >= 0; i-- {
+						it := group[i]
+						if !isDestroyable(it.object) || exists[it.object] {
+								continue
+						}
+						exists[it.object] = true
+						items = append(items, it)
+				}
+		}
+		return items
+}
+
+// 优雅关闭, 超过总时限的组件 记录日志
+func (this *ApplicationImpl) shutdown() {
+		var (
+				timeout  = this.shutdownTimeout()
+				deadline = time.Now().Add(timeout)
+				items    = this.teardownItems()
+		)
+		for i, it := range items {
+				remain := time.Until(deadline)
+				if remain <= 0 {
+						for _, skip := range items[i:] {
+								this.logger().Warn(fmt.Sprintf("shutdown %s skipped, exceeded deadline %s", skip.name, timeout))
+						}
+						return
+				}
+				done := make(chan struct{})
+				go func(name string, obj interface{}) {
+						defer close(done)
+						defer func() {
+								if err := recover(); err != nil {
+										this.logger().Error(fmt.Sprintf("shutdown %s panic: %v", name, err))
+								}
+						}()
+						destroy(obj)
+				}(it.name, it.object)
+				select {
+				case <-done:
+				case <-time.After(remain):
+						this.logger().Warn(fmt.Sprintf("shutdown %s exceeded deadline %s", it.name, timeout))
+				}
+		}
+}
+
+// 关闭总时限
+func (this *ApplicationImpl) shutdownTimeout() time.Duration {
+		switch v := this.GetProfile(ShutdownTimeoutKey).(type) {
+		case time.Duration:
+				if v > 0 {
+						return v
+				}
+		case string:
+				if d, err := time.ParseDuration(v); err == nil && d > 0 {
+						return d
+				}
+		}
+		return ShutdownTimeoutDefault
+}
+
+// 应用日志
+func (this *ApplicationImpl) logger() Components.Logger {
+		if logger, ok := this.Get(Components.LoggerAlias).(Components.Logger); ok {
+				return logger
+		}
+		return stdLogger{}
+}
+
+// 可销毁
+func isDestroyable(obj interface{}) bool {
+		switch obj.(type) {
+		case Contracts.DestroyInterface, interface{ Close() error }, interface{ Close() }:
+				return true
+		}
+		return false
+}
+
+// 销毁 Destroy 优先, 其次 Close
+func destroy(obj interface{}) {
+		switch v := obj.(type) {
+		case Contracts.DestroyInterface:
+				v.Destroy()
+		case interface{ Close() error }:
+				_ = v.Close()
+		case interface{ Close() }:
+				v.Close()
+		}
+}
+
+// 标准日志
+type stdLogger struct{}
+
+func (stdLogger) SetLevel(string) {}
+
+func (stdLogger) Error(args ...interface{}) {
+		log.Println(append([]interface{}{"[error]"}, args...)...)
+}
+
+func (stdLogger) Debug(args ...interface{}) {
+		log.Println(append([]interface{}{"[debug]"}, args...)...)
+}
+
+func (stdLogger) Info(args ...interface{}) {
+		log.Println(append([]interface{}{"[info]"}, args...)...)
+}
+
+func (stdLogger) Warn(args ...interface{}) {
+		log.Println(append([]interface{}{"[warn]"}, args...)...)
+}
@@ -0,0 +1,85 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"strings"
+		"sync"
+		"testing"
+		"time"
+)
+
+type closeRecorder struct {
+		mutex sync.Mutex
+		calls []string
+}
+
+type destroyProvider struct {
+		name     string
+		recorder *closeRecorder
+		delay    time.Duration
+}
+
+type closeConnector struct {
+		name     string
+		recorder *closeRecorder
+}
+
+func (this *closeRecorder) add(name string) {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		this.calls = append(this.calls, name)
+}
+
+func (this *closeRecorder) String() string {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		return strings.Join(this.calls, ",")
+}
+
+func (this *destroyProvider) String() string {
+		return this.name
+}
+
+func (this *destroyProvider) Boot() {
+}
+
+func (this *destroyProvider) Destroy() {
+		time.Sleep(this.delay)
+		this.recorder.add(this.name)
+}
+
+func (this *closeConnector) Close() error {
+		this.recorder.add(this.name)
+		return nil
+}
+
+func TestApplicationShutdown(t *testing.T) {
+		var (
+				app      = newTestApp()
+				recorder = new(closeRecorder)
+				ch       = make(chan int, 2)
+		)
+		app.properties.Store(ctrlChan, ch)
+		app.properties.Store(ShutdownTimeoutKey, 100*time.Millisecond)
+		app.Singleton("redis", func(app Contracts.ApplicationContainer) interface{} {
+				return &closeConnector{name: "redis", recorder: recorder}
+		})
+		app.Singleton("mysql", func(app Contracts.ApplicationContainer) interface{} {
+				return &closeConnector{name: "mysql", recorder: recorder}
+		})
+		app.boot(&destroyProvider{name: "Slow", recorder: recorder, delay: time.Second})
+		app.boot(&destroyProvider{name: "Config", recorder: recorder})
+		app.boot(&destroyProvider{name: "Http", recorder: recorder})
+		app.Get("mysql")
+		app.Get("redis")
+		Convey("Application Shutdown Test", t, func() {
+				start := time.Now()
+				app.Stop()
+				app.Stop()
+				So(time.Since(start), ShouldBeLessThan, time.Second)
+				So(recorder.String(), ShouldEqual, "Http,Config")
+				So(<-ch, ShouldEqual, -1)
+				So(len(ch), ShouldEqual, 0)
+		})
+}
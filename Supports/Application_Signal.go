@@ -0,0 +1,52 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Contracts"
+		"os"
+		"os/signal"
+		"syscall"
+)
+
+const (
+		ReloadEv = "config.reloaded"
+)
+
+// 监听系统信号: SIGINT/SIGTERM 优雅停止, SIGHUP 重新加载配置
+func (this *ApplicationImpl) notifySignals() (chan os.Signal, func()) {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+		return signals, func() {
+				signal.Stop(signals)
+		}
+}
+
+// 处理系统信号
+func (this *ApplicationImpl) handleSignal(sig os.Signal) {
+		this.logger().Info(fmt.Sprintf("[App] receive signal %v", sig))
+		if sig == syscall.SIGHUP {
+				this.Reload()
+				return
+		}
+		go this.Stop()
+}
+
+// 按引导顺序 重新加载 服务提供器 (环境变量,配置加载器), 然后触发 config.reloaded
+func (this *ApplicationImpl) Reload() {
+		for _, it := range this.reloadItems() {
+				it.(Contracts.ReloadInterface).Reload()
+		}
+		this.Emit(ReloadEv, this)
+}
+
+func (this *ApplicationImpl) reloadItems() []interface{} {
+		var items []interface{}
+		this.stateMutex.Lock()
+		defer this.stateMutex.Unlock()
+		for _, it := range this.booted {
+				if _, ok := it.object.(Contracts.ReloadInterface); ok {
+						items = append(items, it.object)
+				}
+		}
+		return items
+}
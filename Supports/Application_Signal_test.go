@@ -0,0 +1,62 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"syscall"
+		"testing"
+		"time"
+)
+
+type reloadProvider struct {
+		destroyProvider
+}
+
+type eventRecorder struct {
+		Components.EventDispatcher
+		recorder *closeRecorder
+}
+
+func (this *reloadProvider) Reload() {
+		this.recorder.add(this.name)
+}
+
+func (this *eventRecorder) Dispatch(event string, payload interface{}) {
+		this.recorder.add(event)
+}
+
+func TestApplicationReload(t *testing.T) {
+		var (
+				app      = newTestApp()
+				recorder = new(closeRecorder)
+		)
+		app.Bind(Components.EventBusProviderClass, &eventRecorder{recorder: recorder})
+		app.boot(&reloadProvider{destroyProvider{name: "Env", recorder: recorder}})
+		app.boot(&destroyProvider{name: "Logger", recorder: recorder})
+		app.boot(&reloadProvider{destroyProvider{name: "Config", recorder: recorder}})
+		Convey("Application Reload Test", t, func() {
+				app.handleSignal(syscall.SIGHUP)
+				So(recorder.String(), ShouldEqual, "Env,Config,"+ReloadEv)
+		})
+}
+
+func TestApplicationSignalStop(t *testing.T) {
+		var (
+				app      = newTestApp()
+				ch       = make(chan int, 2)
+				recorder = new(closeRecorder)
+		)
+		app.properties.Store(ctrlChan, ch)
+		app.Bind(Components.EventBusProviderClass, &eventRecorder{recorder: recorder})
+		Convey("Application Signal Stop Test", t, func() {
+				app.handleSignal(syscall.SIGTERM)
+				select {
+				case v := <-ch:
+						So(v, ShouldEqual, -1)
+				case <-time.After(time.Second):
+						So("stop timeout", ShouldBeEmpty)
+				}
+				// 新旧停止事件 均分发
+				So(recorder.String(), ShouldEqual, AppStoppedEv+","+StopEv)
+		})
+}
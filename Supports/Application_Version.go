@@ -0,0 +1,40 @@
+package Supports
+
+import (
+		"fmt"
+		"runtime"
+)
+
+// 构建信息, 通过 -ldflags 注入, eg:
+// go build -ldflags "-X github.com/webGameLinux/kits/Supports.Version=1.2.0
+//
+//	-X github.com/webGameLinux/kits/Supports.GitCommit=$(git rev-parse --short HEAD)
+//	-X github.com/webGameLinux/kits/Supports.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+		Version   = appVersionName
+		GitCommit = ""
+		BuildTime = ""
+)
+
+// 构建信息
+type BuildInfo struct {
+		Version   string `json:"version"`
+		GitCommit string `json:"git_commit"`
+		BuildTime string `json:"build_time"`
+		GoVersion string `json:"go_version"`
+}
+
+func GetBuildInfo() BuildInfo {
+		return BuildInfo{Version: Version, GitCommit: GitCommit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+}
+
+func (this BuildInfo) String() string {
+		var info = "version " + this.Version
+		if this.GitCommit != "" {
+				info += ", commit " + this.GitCommit
+		}
+		if this.BuildTime != "" {
+				info += ", built " + this.BuildTime
+		}
+		return fmt.Sprintf("%s, %s %s/%s", info, this.GoVersion, runtime.GOOS, runtime.GOARCH)
+}
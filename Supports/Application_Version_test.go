@@ -0,0 +1,28 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"runtime"
+		"testing"
+)
+
+func TestApplicationBuildInfo(t *testing.T) {
+		var version, commit, built = Version, GitCommit, BuildTime
+		defer func() {
+				Version, GitCommit, BuildTime = version, commit, built
+		}()
+		Version, GitCommit, BuildTime = "1.2.0", "a1b2c3d", "2020-05-01T10:00:00Z"
+		Convey("Application Build Info Test", t, func() {
+				var app = NewApp()
+				profiles := app.Profiles()
+				So(profiles["Version"], ShouldEqual, "1.2.0")
+				So(profiles["GitCommit"], ShouldEqual, "a1b2c3d")
+				So(profiles["BuildTime"], ShouldEqual, "2020-05-01T10:00:00Z")
+				So(profiles["GoVersion"], ShouldEqual, runtime.Version())
+				So(app.GetProfile("version"), ShouldEqual, "1.2.0")
+				So(GetBuildInfo().String(), ShouldStartWith, "version 1.2.0, commit a1b2c3d, built 2020-05-01T10:00:00Z, go")
+
+				GitCommit, BuildTime = "", ""
+				So(GetBuildInfo().String(), ShouldStartWith, "version 1.2.0, go")
+		})
+}
@@ -0,0 +1,133 @@
+package Supports
+
+import (
+		"bytes"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"os"
+		"strings"
+		"testing"
+)
+
+type greeter interface {
+		Greet() string
+}
+
+type helloGreeter struct {
+		Word string
+}
+
+type greetService struct {
+		Greeter greeter                        `inject:""`
+		Hello   *helloGreeter                  `inject:"hello"`
+		App     Contracts.ApplicationContainer `inject:"app"`
+}
+
+type brokenService struct {
+		Missing greeter `inject:"missing"`
+		Number  int     `inject:"hello"`
+}
+
+type dependentProvider struct {
+		Components.AppServiceProvider
+		depends []string
+}
+
+func (this *dependentProvider) DependsOn() []string {
+		return this.depends
+}
+
+func newDependentProvider(name string, depends ...string) *dependentProvider {
+		return &dependentProvider{AppServiceProvider: Components.AppServiceProvider{Name: name}, depends: depends}
+}
+
+func (this *helloGreeter) Greet() string {
+		return this.Word
+}
+
+func newTestApp() *ApplicationImpl {
+		var app = newApplication()
+		app.iocInitFactory()
+		app.propertiesInitFactory()
+		return app
+}
+
+func TestApplicationFill(t *testing.T) {
+		var app = newTestApp()
+		app.Bind("hello", &helloGreeter{Word: "hello"})
+		app.Singleton("service", func(app Contracts.ApplicationContainer) interface{} {
+				return new(greetService)
+		})
+		Convey("Application Fill Test", t, func() {
+				var service = new(greetService)
+				So(app.Fill(service), ShouldBeNil)
+				So(service.Greeter.Greet(), ShouldEqual, "hello")
+				So(service.Hello.Word, ShouldEqual, "hello")
+				So(service.App, ShouldEqual, app)
+				Convey("singleton autowire", func() {
+						obj := app.Get("service").(*greetService)
+						So(obj.Hello, ShouldNotBeNil)
+				})
+				Convey("unresolvable fields", func() {
+						err := app.Fill(new(brokenService))
+						So(err, ShouldNotBeNil)
+						So(strings.Contains(err.Error(), "Missing<missing>"), ShouldBeTrue)
+						So(strings.Contains(err.Error(), "Number<hello, got *Supports.helloGreeter>"), ShouldBeTrue)
+				})
+		})
+}
+
+func TestApplicationMake(t *testing.T) {
+		var app = newTestApp()
+		app.Bind("hello", &helloGreeter{Word: "make"})
+		Convey("Application Make Test", t, func() {
+				var (
+						g     greeter
+						hello *helloGreeter
+				)
+				So(app.Make(&g), ShouldBeNil)
+				So(g.Greet(), ShouldEqual, "make")
+				So(app.Make(&hello, "hello"), ShouldBeNil)
+				So(hello.Word, ShouldEqual, "make")
+				So(app.Make(helloGreeter{}), ShouldNotBeNil)
+				var count int
+				So(app.Make(&count), ShouldNotBeNil)
+		})
+}
+
+func TestApplicationDependencyError(t *testing.T) {
+		var args = os.Args
+		defer func() {
+				os.Args = args
+		}()
+		var newApp = func(providers ...Contracts.Provider) *ApplicationImpl {
+				var app = NewApp()
+				app.properties.Store(ctrlChan, make(chan int, 2))
+				for _, provider := range providers {
+						app.Register(provider)
+				}
+				return app
+		}
+		Convey("Application Dependency Error Test", t, func() {
+				app := newApp(newDependentProvider("CycleA", "CycleB"), newDependentProvider("CycleB", "CycleA"))
+				err := app.LoadCoreProviders()
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "provider dependency cycle")
+				So(app.isInit(registerName), ShouldBeFalse)
+
+				app = newApp(newDependentProvider("Orphan", "NoSuchProvider"))
+				err = app.providersInit()
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "Orphan depends on unregistered provider NoSuchProvider")
+
+				// 控制台命令 加载失败时 返回非零退出码
+				os.Args = []string{"kits", ContainerListCommand}
+				app = newApp(newDependentProvider("CycleA", "CycleB"), newDependentProvider("CycleB", "CycleA"))
+				var buf bytes.Buffer
+				code, ok := app.HandleCommand(&buf)
+				So(ok, ShouldBeTrue)
+				So(code, ShouldEqual, Components.ExitFailure)
+				So(buf.String(), ShouldBeEmpty)
+		})
+}
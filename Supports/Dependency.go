@@ -0,0 +1,125 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Contracts"
+		"github.com/webGameLinux/kits/Libs/Errors"
+		"strings"
+)
+
+// 获取服务提供器名
+func ProviderName(v interface{}) string {
+		if provider, ok := v.(Contracts.Provider); ok {
+				if clazz := provider.GetClazz(); clazz != nil && clazz.String() != "" {
+						return clazz.String()
+				}
+		}
+		if str, ok := v.(fmt.Stringer); ok {
+				return str.String()
+		}
+		return fmt.Sprintf("%T", v)
+}
+
+// 获取依赖
+func ProviderDependencies(v interface{}) []string {
+		if dep, ok := v.(Contracts.DependsOnInterface); ok {
+				return dep.DependsOn()
+		}
+		return []string{}
+}
+
+// 按依赖关系排序,同层保持原有顺序
+// items 待排序服务提供器
+// known 不在 items 中的依赖是否已满足
+func SortByDependency(items []interface{}, known func(string) bool) ([]interface{}, error) {
+		var (
+				sorted  []interface{}
+				pending = make(map[string]bool)
+				placed  = make(map[string]bool)
+		)
+		for _, it := range items {
+				pending[ProviderName(it)] = true
+		}
+		for _, it := range items {
+				name := ProviderName(it)
+				for _, dep := range ProviderDependencies(it) {
+						if pending[dep] || (known != nil && known(dep)) {
+								continue
+						}
+						return nil, Errors.DependencyError(fmt.Sprintf("provider %s depends on unregistered provider %s", name, dep))
+				}
+		}
+		for len(items) > 0 {
+				var (
+						next []interface{}
+						ok   bool
+				)
+				for _, it := range items {
+						if ok || !dependsPlaced(it, pending, placed) {
+								next = append(next, it)
+								continue
+						}
+						sorted = append(sorted, it)
+						placed[ProviderName(it)] = true
+						ok = true
+				}
+				if !ok {
+						return nil, Errors.DependencyError("provider dependency cycle " + dependencyCycle(next))
+				}
+				items = next
+		}
+		return sorted, nil
+}
+
+// 依赖是否都已排序
+func dependsPlaced(it interface{}, pending, placed map[string]bool) bool {
+		for _, dep := range ProviderDependencies(it) {
+				if pending[dep] && !placed[dep] {
+						return false
+				}
+		}
+		return true
+}
+
+// 查找环 eg: A -> B -> A
+func dependencyCycle(items []interface{}) string {
+		var (
+				graph = make(map[string][]string)
+				names []string
+		)
+		for _, it := range items {
+				name := ProviderName(it)
+				names = append(names, name)
+				graph[name] = ProviderDependencies(it)
+		}
+		for _, start := range names {
+				var (
+						path    = []string{start}
+						visited = map[string]bool{start: true}
+						current = start
+				)
+				for {
+						var next string
+						for _, dep := range graph[current] {
+								if _, ok := graph[dep]; ok {
+										next = dep
+										break
+								}
+						}
+						if next == "" {
+								break
+						}
+						if visited[next] {
+								for i, name := range path {
+										if name == next {
+												return strings.Join(append(path[i:], next), " -> ")
+										}
+								}
+						}
+						visited[next] = true
+						path = append(path, next)
+						current = next
+				}
+		}
+		return strings.Join(names, ", ")
+}
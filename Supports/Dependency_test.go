@@ -0,0 +1,64 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"strings"
+		"testing"
+)
+
+type dependProvider struct {
+		name    string
+		depends []string
+}
+
+func (this *dependProvider) String() string {
+		return this.name
+}
+
+func (this *dependProvider) DependsOn() []string {
+		return this.depends
+}
+
+func dependProviderOf(name string, depends ...string) *dependProvider {
+		return &dependProvider{name: name, depends: depends}
+}
+
+func names(items []interface{}) []string {
+		var arr []string
+		for _, it := range items {
+				arr = append(arr, ProviderName(it))
+		}
+		return arr
+}
+
+func TestSortByDependency(t *testing.T) {
+		Convey("Provider Dependency Sort Test", t, func() {
+				sorted, err := SortByDependency([]interface{}{
+						dependProviderOf("Iris", "Configure"),
+						dependProviderOf("Logger", "Configure"),
+						dependProviderOf("Configure", "Environment"),
+						dependProviderOf("Environment"),
+				}, nil)
+				So(err, ShouldBeNil)
+				So(strings.Join(names(sorted), ","), ShouldEqual, "Environment,Configure,Iris,Logger")
+				Convey("unregistered dependency", func() {
+						_, err := SortByDependency([]interface{}{dependProviderOf("Iris", "Configure")}, nil)
+						So(err, ShouldNotBeNil)
+						So(strings.Contains(err.Error(), "Iris depends on unregistered provider Configure"), ShouldBeTrue)
+						_, err = SortByDependency([]interface{}{dependProviderOf("Iris", "Configure")}, func(name string) bool {
+								return name == "Configure"
+						})
+						So(err, ShouldBeNil)
+				})
+				Convey("dependency cycle", func() {
+						_, err := SortByDependency([]interface{}{
+								dependProviderOf("Environment"),
+								dependProviderOf("A", "B"),
+								dependProviderOf("B", "C"),
+								dependProviderOf("C", "A", "Environment"),
+						}, nil)
+						So(err, ShouldNotBeNil)
+						So(strings.Contains(err.Error(), "A -> B -> C -> A"), ShouldBeTrue)
+				})
+		})
+}
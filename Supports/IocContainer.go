@@ -10,16 +10,25 @@ const (
 	SINGLETON        = "singleton"
 	REAL_CLAZZ       = "clazz"
 	SINGLETON_OBJECT = "singleton_object"
+	SCOPED           = "scoped"
+	maxAliasDepth    = 32
 )
 
 type ContainerApp interface {
 	Contracts.Container
+	Scoped(string, func(app Contracts.ApplicationContainer) interface{})
 	Resolver(string) *entry
+	Update(string, func(*entry))
+	Aliases() map[string]string
 }
 
+// 容器, map 索引, 保留注册顺序
 type ContainerImpl struct {
-	items []*entry
-	mutex sync.Mutex
+	items   map[string]*entry
+	order   []string
+	aliases map[string]string
+	tags    map[string][]string
+	mutex   sync.RWMutex
 }
 
 // 子项
@@ -27,6 +36,10 @@ type entry struct {
 	key    string
 	value  interface{}
 	extras *Extras
+	once   sync.Once
+	err    error
+	// 保护 value
+	mutex sync.Mutex
 }
 
 // 扩展信息
@@ -76,9 +89,6 @@ func Entry(v interface{}) (*entry, bool) {
 	if v == nil {
 		return nil, false
 	}
-	if it, ok := v.(entry); ok {
-		return &it, true
-	}
 	if it, ok := v.(*entry); ok {
 		return it, true
 	}
@@ -88,10 +98,11 @@ func Entry(v interface{}) (*entry, bool) {
 // 容器
 func ContainerOf(items ...*entry) ContainerApp {
 	var container = new(ContainerImpl)
-	if len(items) > 0 {
-		for _, item := range items {
-			container.items = append(container.items, item)
-		}
+	container.items = make(map[string]*entry)
+	container.aliases = make(map[string]string)
+	container.tags = make(map[string][]string)
+	for _, item := range items {
+		container.add(item)
 	}
 	return container
 }
@@ -126,6 +137,8 @@ func (this *entry) Extras() *Extras {
 
 // 值
 func (this *entry) Value() interface{} {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
 	return this.value
 }
 
@@ -137,85 +150,185 @@ func (this *entry) SetKey(key string) *entry {
 
 // 更新 value
 func (this *entry) SetValue(v interface{}) *entry {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
 	this.value = v
 	return this
 }
 
-func (this *ContainerImpl) Get(id string) interface{} {
-	for _, en := range this.items {
-		if en.key == id {
-			return en
+// 单例 只构造一次, 并发时 等待首次构造完成, 构造失败的错误 被缓存
+// 循环依赖 由解析栈检测, 构造中 绕过解析栈 再次解析自身 会阻塞
+func (this *entry) Singleton(build func() (interface{}, error)) (interface{}, error) {
+	if obj, ok := this.extras.Load(SINGLETON_OBJECT); ok {
+		return obj, nil
+	}
+	this.once.Do(func() {
+		var instance interface{}
+		instance, this.err = build()
+		if this.err == nil && instance != nil {
+			this.extras.Store(SINGLETON_OBJECT, instance)
 		}
+	})
+	if this.err != nil {
+		return nil, this.err
+	}
+	obj, _ := this.extras.Load(SINGLETON_OBJECT)
+	return obj, nil
+}
+
+func (this *ContainerImpl) Get(id string) interface{} {
+	if en := this.Resolver(id); en != nil {
+		return en
 	}
 	return nil
 }
 
+// 别名, 解析时 指向 clazz 对应的服务
 func (this *ContainerImpl) Alias(clazz string, alias string) {
-	en := this.Resolver(clazz)
-	if en == nil {
+	if clazz == "" || alias == "" || clazz == alias {
 		return
 	}
-	extras := Extrasof()
-	if class, ok := en.extras.Load(REAL_CLAZZ); ok {
-		extras.Store(REAL_CLAZZ, class)
-	} else {
-		extras.Store(REAL_CLAZZ, en.value)
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if _, ok := this.items[alias]; ok {
+		return
 	}
-	this.add(EntryOf(alias, clazz, extras))
+	this.aliases[alias] = clazz
 }
 
-func (this *ContainerImpl) Bind(id string, object interface{}) {
-	if !this.Exists(id) {
-		it := EntryOf(id, object)
-		it.extras.Store(BIND, true)
-		this.add(it)
+// 所有别名 alias => clazz
+func (this *ContainerImpl) Aliases() map[string]string {
+	var aliases = make(map[string]string)
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	for alias, clazz := range this.aliases {
+		aliases[alias] = clazz
 	}
+	return aliases
+}
+
+func (this *ContainerImpl) Bind(id string, object interface{}) {
+	it := EntryOf(id, object)
+	it.extras.Store(BIND, true)
+	this.add(it)
 }
 
 func (this *ContainerImpl) Singleton(id string, factory func(app Contracts.ApplicationContainer) interface{}) {
-	if this.Exists(id) {
-		return
-	}
 	it := EntryOf(id, factory)
 	it.extras.Store(SINGLETON, true)
 	this.add(it)
 }
 
+// 作用域内单例, 每个作用域一个实例
+func (this *ContainerImpl) Scoped(id string, factory func(app Contracts.ApplicationContainer) interface{}) {
+	it := EntryOf(id, factory)
+	it.extras.Store(SCOPED, true)
+	this.add(it)
+}
+
+// 移除服务, 为空时 清空容器
 func (this *ContainerImpl) Destroy(ids ...string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
 	if len(ids) == 0 {
-		this.items = []*entry{}
+		this.items = make(map[string]*entry)
+		this.aliases = make(map[string]string)
+		this.order = nil
+		return
+	}
+	for _, id := range ids {
+		if _, ok := this.items[id]; ok {
+			delete(this.items, id)
+			this.order = removeString(this.order, id)
+		}
+		delete(this.aliases, id)
 	}
 }
 
+// 服务 id, 按注册顺序 (不含别名)
 func (this *ContainerImpl) Keys() []string {
-	var keys []string
-	for _, en := range this.items {
-		keys = append(keys, en.Key())
-	}
-	return keys
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return append([]string{}, this.order...)
 }
 
 func (this *ContainerImpl) Exists(id string) bool {
-	for _, en := range this.items {
-		if en.Key() == id {
-			return true
+	return this.Resolver(id) != nil
+}
+
+// 给服务打标签, 同一标签下 按打标签顺序 去重
+func (this *ContainerImpl) Tag(ids []string, tags ...string) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	for _, tag := range tags {
+		for _, id := range ids {
+			if !inStrings(this.tags[tag], id) {
+				this.tags[tag] = append(this.tags[tag], id)
+			}
 		}
 	}
-	return false
 }
 
+// 标签下的服务 id
+func (this *ContainerImpl) Tagged(tag string) []string {
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	return append([]string{}, this.tags[tag]...)
+}
+
+// 获取服务项, 解析别名
 func (this *ContainerImpl) Resolver(id string) *entry {
-	en := this.Get(id)
-	if en == nil {
-		return nil
-	}
-	if obj, ok := en.(*entry); ok {
-		return obj
+	this.mutex.RLock()
+	defer this.mutex.RUnlock()
+	for i := 0; i < maxAliasDepth; i++ {
+		if en, ok := this.items[id]; ok {
+			return en
+		}
+		clazz, ok := this.aliases[id]
+		if !ok {
+			return nil
+		}
+		id = clazz
 	}
 	return nil
 }
 
+// 加锁 更新服务项, 不存在时 忽略
+func (this *ContainerImpl) Update(id string, update func(*entry)) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if en, ok := this.items[id]; ok {
+		update(en)
+	}
+}
+
+// 添加服务项, 已存在时 忽略
 func (this *ContainerImpl) add(it *entry) *ContainerImpl {
-	this.items = append(this.items, it)
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	if _, ok := this.items[it.key]; ok {
+		return this
+	}
+	this.items[it.key] = it
+	this.order = append(this.order, it.key)
 	return this
 }
+
+func inStrings(items []string, value string) bool {
+	for _, it := range items {
+		if it == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(items []string, value string) []string {
+	var result = make([]string, 0, len(items))
+	for _, it := range items {
+		if it != value {
+			result = append(result, it)
+		}
+	}
+	return result
+}
@@ -0,0 +1,281 @@
+package Supports
+
+import (
+		"errors"
+		"fmt"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Contracts"
+		"sync"
+		"sync/atomic"
+		"testing"
+		"time"
+)
+
+// 重构前的 切片容器, 用于基准对比
+type legacyContainer struct {
+		items []*entry
+		mutex sync.Mutex
+}
+
+func (this *legacyContainer) Bind(id string, object interface{}) {
+		if !this.Exists(id) {
+				this.mutex.Lock()
+				this.items = append(this.items, EntryOf(id, object))
+				this.mutex.Unlock()
+		}
+}
+
+func (this *legacyContainer) Exists(id string) bool {
+		return this.Resolver(id) != nil
+}
+
+func (this *legacyContainer) Resolver(id string) *entry {
+		this.mutex.Lock()
+		defer this.mutex.Unlock()
+		for _, en := range this.items {
+				if en.key == id {
+						return en
+				}
+		}
+		return nil
+}
+
+// 重构前的 app.Get, 已构造的单例 从 SINGLETON_OBJECT 返回
+func (this *legacyContainer) Get(id string) interface{} {
+		if !this.Exists(id) {
+				return nil
+		}
+		en := this.Resolver(id)
+		if en.Extras().Bool(SCOPED) {
+				return nil
+		}
+		if !en.Extras().Bool(SINGLETON) {
+				if class, ok := en.Extras().Load(REAL_CLAZZ); ok && class != nil {
+						return class
+				}
+				return en.value
+		}
+		obj, _ := en.Extras().Load(SINGLETON_OBJECT)
+		return obj
+}
+
+type redisClient struct {
+		id int32
+}
+
+func TestContainerIndex(t *testing.T) {
+		var container = ContainerOf()
+		container.Bind("config", "file")
+		container.Bind("logger", "stdout")
+		container.Bind("config", "ignored")
+		container.Alias("config", "configure")
+		container.Alias("configure", "configuration")
+		container.Alias("a", "b")
+		container.Alias("b", "a")
+		Convey("Container Index Test", t, func() {
+				So(container.Keys(), ShouldResemble, []string{"config", "logger"})
+				So(container.Resolver("config").Value(), ShouldEqual, "file")
+				So(container.Resolver("configuration").Value(), ShouldEqual, "file")
+				So(container.Exists("configure"), ShouldBeTrue)
+				So(container.Exists("a"), ShouldBeFalse)
+				So(container.Aliases()["configure"], ShouldEqual, "config")
+				container.Destroy("logger")
+				So(container.Exists("logger"), ShouldBeFalse)
+				So(container.Keys(), ShouldResemble, []string{"config"})
+				container.Destroy()
+				So(container.Exists("configuration"), ShouldBeFalse)
+		})
+}
+
+func TestContainerSingletonOnce(t *testing.T) {
+		var (
+				app     = newTestApp()
+				created int32
+				wg      sync.WaitGroup
+				clients = make([]interface{}, 64)
+		)
+		app.Singleton("redis", func(app Contracts.ApplicationContainer) interface{} {
+				time.Sleep(10 * time.Millisecond)
+				return &redisClient{id: atomic.AddInt32(&created, 1)}
+		})
+		app.Alias("redis", "cache")
+		for i := range clients {
+				wg.Add(1)
+				go func(i int) {
+						defer wg.Done()
+						if i%2 == 0 {
+								clients[i] = app.Get("redis")
+						} else {
+								clients[i] = app.Get("cache")
+						}
+				}(i)
+		}
+		wg.Wait()
+		Convey("Container Singleton Once Test", t, func() {
+				So(created, ShouldEqual, 1)
+				for _, client := range clients {
+						So(client, ShouldEqual, clients[0])
+				}
+		})
+}
+
+func TestContainerResolveError(t *testing.T) {
+		var (
+				app   = newTestApp()
+				calls int32
+		)
+		app.Singleton("mysql", func(app Contracts.ApplicationContainer) interface{} {
+				atomic.AddInt32(&calls, 1)
+				return errors.New("connection refused")
+		})
+		app.Singleton("etcd", func(app Contracts.ApplicationContainer) interface{} {
+				panic("no endpoints")
+		})
+		Convey("Container Resolve Error Test", t, func() {
+				obj, err := app.Resolve("mysql")
+				So(obj, ShouldBeNil)
+				So(err.Error(), ShouldContainSubstring, "connection refused")
+				_, again := app.Resolve("mysql")
+				So(again, ShouldEqual, err)
+				So(app.Get("mysql"), ShouldBeNil)
+				So(calls, ShouldEqual, 1)
+				_, err = app.Resolve("etcd")
+				So(err.Error(), ShouldContainSubstring, "no endpoints")
+				_, err = app.Resolve("unknown")
+				So(err, ShouldNotBeNil)
+		})
+}
+
+func TestContainerConcurrentAccess(t *testing.T) {
+		var (
+				app = newTestApp()
+				wg  sync.WaitGroup
+		)
+		for i := 0; i < 32; i++ {
+				wg.Add(1)
+				go func(i int) {
+						defer wg.Done()
+						id := fmt.Sprintf("service.%d", i)
+						app.Bind(id, i)
+						app.Alias(id, "alias."+id)
+						app.Singleton("singleton."+id, func(app Contracts.ApplicationContainer) interface{} {
+								return app.Get(id)
+						})
+						for j := 0; j < 32; j++ {
+								app.Get(fmt.Sprintf("singleton.service.%d", j))
+								app.Exists(fmt.Sprintf("alias.service.%d", j))
+						}
+				}(i)
+		}
+		wg.Wait()
+		Convey("Container Concurrent Access Test", t, func() {
+				for i := 0; i < 32; i++ {
+						So(app.Get(fmt.Sprintf("alias.service.%d", i)), ShouldEqual, i)
+						So(app.Get(fmt.Sprintf("singleton.service.%d", i)), ShouldEqual, i)
+				}
+		})
+}
+
+func benchmarkIds(n int) []string {
+		var ids = make([]string, n)
+		for i := range ids {
+				ids[i] = fmt.Sprintf("service.%d", i)
+		}
+		return ids
+}
+
+func BenchmarkLegacyContainerResolver(b *testing.B) {
+		var (
+				container = new(legacyContainer)
+				ids       = benchmarkIds(200)
+		)
+		for _, id := range ids {
+				container.Bind(id, id)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+				container.Resolver(ids[i%len(ids)])
+		}
+}
+
+func BenchmarkContainerResolver(b *testing.B) {
+		var (
+				container = ContainerOf()
+				ids       = benchmarkIds(200)
+		)
+		for _, id := range ids {
+				container.Bind(id, id)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+				container.Resolver(ids[i%len(ids)])
+		}
+}
+
+func BenchmarkLegacyContainerResolverParallel(b *testing.B) {
+		var (
+				container = new(legacyContainer)
+				ids       = benchmarkIds(200)
+		)
+		for _, id := range ids {
+				container.Bind(id, id)
+		}
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+						container.Resolver(ids[i%len(ids)])
+				}
+		})
+}
+
+func BenchmarkContainerResolverParallel(b *testing.B) {
+		var (
+				container = ContainerOf()
+				ids       = benchmarkIds(200)
+		)
+		for _, id := range ids {
+				container.Bind(id, id)
+		}
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+						container.Resolver(ids[i%len(ids)])
+				}
+		})
+}
+
+func BenchmarkLegacyApplicationGetSingleton(b *testing.B) {
+		var (
+				container = new(legacyContainer)
+				redis     = EntryOf("redis", nil)
+		)
+		for _, id := range benchmarkIds(200) {
+				container.Bind(id, id)
+		}
+		redis.extras.Store(SINGLETON, true)
+		redis.extras.Store(SINGLETON_OBJECT, new(redisClient))
+		container.items = append(container.items, redis)
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+						container.Get("redis")
+				}
+		})
+}
+
+func BenchmarkApplicationGetSingleton(b *testing.B) {
+		var app = newTestApp()
+		for _, id := range benchmarkIds(200) {
+				app.Bind(id, id)
+		}
+		app.Singleton("redis", func(app Contracts.ApplicationContainer) interface{} {
+				return new(redisClient)
+		})
+		app.Get("redis")
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+						app.Get("redis")
+				}
+		})
+}
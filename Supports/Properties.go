@@ -2,11 +2,13 @@ package Supports
 
 import (
 		"fmt"
+		"github.com/webGameLinux/kits/Components"
 		"github.com/webGameLinux/kits/Contracts"
 		"io"
 		"os"
 		"strings"
 		"sync"
+		"time"
 )
 
 type Properties struct {
@@ -17,6 +19,9 @@ type Properties struct {
 		commandStop bool
 		options     map[string]map[string]string
 		cache       map[string]string
+		parsed      *ParsedOptions
+		overrides   map[string]string
+		err         error
 }
 
 var (
@@ -24,15 +29,20 @@ var (
 		propertiesInstance     *Properties
 		optionsMapper          = map[string][]string{
 				"appFile": {"-f", "--file", "-c", "@tip:主配置文件 @eg:-f /path/app.properties "},
-				"paths":   {"-p", "--paths", "@tip:配置目录 @eg:--paths=/paths,/path2"},
+				"paths":   {"-p", "--paths", "@tip:配置目录 @eg:--paths=/paths,/path2 @multi"},
 				"reader":  {"-r", "--reader", "@tip:读取器 @eg: --reader=/paths/a.ini"},
 				"mode":    {"-m", "--mode", "@tip:运行环境 @eg: --mode=test"},
+				"daemon":  {"--daemon", "@tip:后台运行"},
+				"set":     {"-D", "--set", "@tip:覆盖配置 @eg:--set http.port=9090 @multi"},
 				"help":    {"-h", "--help", "@@"},
+				"verbose": {"-v", "--verbose", "@tip:日志详细程度, -v: debug, -vv: trace"},
+				"version": {"-V", "--version", "@tip:版本信息"},
 		}
 )
 
 const (
-		HelpStop = Contracts.HelpStop
+		HelpStop     = Contracts.HelpStop
+		setOptionKey = "set"
 )
 
 func AppBasePropertiesOf() *Properties {
@@ -50,6 +60,7 @@ func newProperties() {
 		propertiesInstance = new(Properties)
 		propertiesInstance.paths = []string{}
 		propertiesInstance.options = map[string]map[string]string{}
+		propertiesInstance.parsed = newParsedOptions()
 }
 
 func (this *Properties) GetReader() io.Reader {
@@ -58,7 +69,7 @@ func (this *Properties) GetReader() io.Reader {
 
 func (this *Properties) Keys() []string {
 		var (
-				defaults = []string{"reader", "appFile", "paths", "cStop"}
+				defaults = []string{"reader", "appFile", "paths", "cStop", setOptionKey}
 				mapper   = this.GetOptions()
 		)
 		if len(mapper) != 0 {
@@ -147,6 +158,10 @@ func (this *Properties) Get(key string) interface{} {
 		case "cStop":
 		case HelpStop:
 				return this.commandStop
+		case Contracts.AppConfigOverrides:
+				return this.Overrides()
+		case Contracts.AppVerbose:
+				return this.parsed.Count("verbose")
 		}
 		if v := this.get(key); v != "" {
 				return v
@@ -198,12 +213,80 @@ func (this *Properties) Init() {
 		this.initEnv()
 		this.initArgs()
 		this.init = true
+		// 参数错误, 输出帮助 并停止
+		if this.err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n\n", this.err)
+				OptionsUsage(os.Stderr)
+				this.stop()
+				return
+		}
+		// 控制台命令 由命令内核输出帮助
+		if Components.CommandName(os.Args, globalOption) != "" {
+				return
+		}
 		this.help()
+		this.version()
+}
+
+// 命令行 --set key=value / -Dkey=value 覆盖的配置
+func (this *Properties) Overrides() map[string]string {
+		var overrides = make(map[string]string, len(this.overrides))
+		for key, value := range this.overrides {
+				overrides[key] = value
+		}
+		return overrides
+}
+
+// 解析 key=value, 同名 后者覆盖
+func parseOverrides(items []string) (map[string]string, error) {
+		var overrides = make(map[string]string)
+		for _, item := range items {
+				kv := strings.SplitN(item, "=", 2)
+				if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+						return nil, fmt.Errorf("invalid --set %q, expected key=value", item)
+				}
+				overrides[strings.TrimSpace(kv[0])] = kv[1]
+		}
+		return overrides, nil
+}
+
+// 命令行参数错误
+func (this *Properties) Err() error {
+		return this.err
+}
+
+// 命令行选项 解析结果, 未设置的选项 取环境变量
+func (this *Properties) Options() *ParsedOptions {
+		return this.parsed
+}
+
+func (this *Properties) Bool(key string) bool {
+		return this.parsed.Bool(key)
+}
+
+func (this *Properties) Int(key string) int {
+		return this.parsed.Int(key)
+}
+
+func (this *Properties) Duration(key string) time.Duration {
+		return this.parsed.Duration(key)
+}
+
+func (this *Properties) Strings(key string) []string {
+		return this.parsed.Strings(key)
+}
+
+// 输出构建信息 并停止
+func (this *Properties) version() {
+		if this.Bool("version") {
+				fmt.Println(GetBuildInfo().String())
+				this.stop()
+		}
 }
 
 func (this *Properties) help() {
-		if h, ok := this.GetOptions()["help"]; ok && h != "" {
-				this.menu()
+		if this.Bool("help") {
+				OptionsUsage(os.Stdout)
 				this.stop()
 		}
 }
@@ -214,21 +297,6 @@ func (this *Properties) stop() {
 		this.options["cStop"] = map[string]string{"ok": "true"}
 }
 
-func (this *Properties) menu() {
-		fmt.Println("  commander help options :")
-		fmt.Println("  		commander [options] ")
-		for key, arr := range GetOptions() {
-				v := arr[len(arr)-1]
-				if key == "help" {
-						v = strings.Replace(v, "@@", "help show menu", 1)
-				} else {
-						v = strings.Replace(v, "@tip:", " ", 1)
-				}
-				arr[len(arr)-1] = strings.Replace(v, "@eg:", "eg: ", 1)
-				fmt.Printf("		 %s :  %s \n", key, strings.Join(arr, " "))
-		}
-}
-
 func (this *Properties) initEnv() {
 		this.loaderEnv()
 		for key, val := range this.GetOptions() {
@@ -331,6 +399,10 @@ func (this *Properties) With(key string) string {
 				return "App.Properties.Paths"
 		case "cStop":
 				return HelpStop
+		case setOptionKey:
+				return Contracts.AppConfigOverrides
+		case "verbose":
+				return Contracts.AppVerbose
 		}
 		return key
 }
@@ -365,62 +437,63 @@ func (this *Properties) Configure(loaderInterface Contracts.PropertyLoaderInterf
 		}
 }
 
+// 解析命令行选项, 命令模式下 命令名后 仅解析全局长选项, 其余参数 由命令处理
 func (this *Properties) parse() {
-		if this.init {
+		if this.init || len(os.Args) < 2 {
 				return
 		}
 		var (
-				val    string
-				args   = os.Args
-				values []string
-				option []map[string]string
+				args   = os.Args[1:]
+				specs  = OptionSpecs()
+				strict = true
+				index  = Components.CommandIndex(os.Args, globalOption)
 		)
-		if len(args) < 2 {
+		if index > 0 {
+				args, specs, strict = os.Args[index+1:], commandOptionSpecs(), false
+		}
+		parsed, err := ParseOptions(args, specs, strict)
+		// 命令名前的全局选项
+		if err == nil && index > 1 {
+				var global *ParsedOptions
+				if global, err = ParseOptions(os.Args[1:index], specs, true); err == nil {
+						global.merge(parsed)
+						parsed = global
+				}
+		}
+		if err == nil {
+				this.overrides, err = parseOverrides(parsed.values[setOptionKey])
+		}
+		if err != nil {
+				this.err = err
 				return
 		}
-		for _, arg := range args[1:] {
-				tmp := []rune(arg)
-				if len(arg) < 2 {
-						values = this.appendOrPop(arg, values, &option)
-						continue
+		// 环境变量 作为默认值
+		for key, value := range this.GetOptions() {
+				if value != "" && !parsed.Has(key) && key != setOptionKey {
+						parsed.values[key] = []string{value}
 				}
-				if tmp[0] != '-' {
-						values = this.appendOrPop(arg, values, &option)
-						continue
-				}
-				val = ""
-				if strings.Contains(arg, "=") {
-						arr := strings.SplitN(arg, "=", 2)
-						arg = arr[0]
-						if len(arr) >= 2 {
-								val = arr[1]
-						}
-				}
-				opt, _ := this.FindOption(arg)
-				if opt == "" {
-						if val != "" {
-								k := strings.Replace(arg, "-", "", 2)
-								this.options[k] = map[string]string{arg: val}
-								this.updateCache(k, val)
-						}
+		}
+		this.parsed = parsed
+		for _, key := range parsed.Keys() {
+				if key == setOptionKey {
 						continue
 				}
-				if val != "" {
-						this.options[opt] = map[string]string{arg: val}
-						this.updateCache(opt, val)
-				} else {
-						option = append(option, map[string]string{opt: arg})
+				var value = strings.Join(parsed.values[key], ",")
+				if !isMultiOption(specs, key) {
+						value = parsed.String(key)
 				}
+				this.options[key] = map[string]string{key: value}
+				this.updateCache(key, value)
 		}
-		// true value fill
-		if len(option) > 0 {
-				for _, it := range option {
-						for k, v := range it {
-								this.options[k] = map[string]string{v: "true"}
-								this.updateCache(k, "true")
-						}
+}
+
+func isMultiOption(specs []*OptionSpec, key string) bool {
+		for _, spec := range specs {
+				if spec.Key == key {
+						return spec.Multi
 				}
 		}
+		return false
 }
 
 func (this *Properties) updateCache(key string, val string) {
@@ -457,34 +530,6 @@ func (this *Properties) loaderEnv() {
 		}
 }
 
-// 出存
-func (this *Properties) appendOrPop(arg string, arr []string, options *[]map[string]string) []string {
-		arr = append(arr, arg)
-		if len(*options) <= 0 {
-				return arr
-		}
-		var (
-				i     int
-				count = len(arr)
-				m     map[string]string
-		)
-		for i, m = range *options {
-				if i >= count {
-						break
-				}
-				for key, t := range m {
-						this.options[key] = map[string]string{t: arr[i]}
-						this.updateCache(key, arr[i])
-				}
-		}
-		if i+1 == count {
-				*options = (*options)[0:0]
-				return arr[0:0]
-		}
-		*options = (*options)[i:]
-		return arr[i:]
-}
-
 func ParseEnvStr(key string) string {
 		var (
 				count  int
@@ -0,0 +1,369 @@
+package Supports
+
+import (
+		"fmt"
+		"github.com/webGameLinux/kits/Components"
+		"io"
+		"os"
+		"path/filepath"
+		"regexp"
+		"sort"
+		"strings"
+		"text/tabwriter"
+		"time"
+)
+
+var optionMeta = regexp.MustCompile(`@(\w+)(?::([^@]*))?`)
+
+// 命令行选项定义, 由 optionsMapper 生成: 选项名..., 元数据
+// 元数据: @tip:说明 @eg:示例 @type:类型 @multi 可重复, 含 @eg 或 @type 的选项 需要取值
+type OptionSpec struct {
+		Key     string
+		Flags   []string
+		Tip     string
+		Example string
+		Type    string
+		Multi   bool
+}
+
+// 是否需要取值, 否则为开关
+func (this *OptionSpec) Value() bool {
+		return this.Type != Components.BoolValue
+}
+
+// 长选项名
+func (this *OptionSpec) Long() string {
+		for _, flag := range this.Flags {
+				if strings.HasPrefix(flag, "--") {
+						return flag
+				}
+		}
+		return ""
+}
+
+func (this *OptionSpec) match(flag string, shorts bool) bool {
+		for _, it := range this.Flags {
+				if it == flag && (shorts || strings.HasPrefix(it, "--")) {
+						return true
+				}
+		}
+		return false
+}
+
+// 全局选项定义, 按 key 排序
+func OptionSpecs() []*OptionSpec {
+		var (
+				mapper = GetOptions()
+				keys   = make([]string, 0, len(mapper))
+				specs  []*OptionSpec
+		)
+		for key := range mapper {
+				keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+				var (
+						arr  = mapper[key]
+						meta = arr[len(arr)-1]
+						spec = &OptionSpec{Key: key, Flags: arr[:len(arr)-1], Tip: key, Type: Components.BoolValue}
+				)
+				if meta == "@@" {
+						spec.Tip = "help show menu"
+				}
+				for _, match := range optionMeta.FindAllStringSubmatch(meta, -1) {
+						var value = strings.TrimSpace(match[2])
+						switch match[1] {
+						case "tip":
+								spec.Tip = value
+						case "eg":
+								spec.Example = value
+								if spec.Type == Components.BoolValue {
+										spec.Type = Components.StringValue
+								}
+						case "type":
+								spec.Type = value
+						case "multi":
+								spec.Multi = true
+						}
+				}
+				specs = append(specs, spec)
+		}
+		return specs
+}
+
+// 命令模式下 生效的全局选项, 仅长选项, -h/--help -V/--version 由命令处理
+func commandOptionSpecs() []*OptionSpec {
+		var specs []*OptionSpec
+		for _, spec := range OptionSpecs() {
+				if spec.Key != "help" && spec.Key != "version" {
+						specs = append(specs, spec)
+				}
+		}
+		return specs
+}
+
+// 命令名前的全局选项 查找, 同命令模式生效的选项, 支持组合短开关 -abc 及 -pVALUE
+func globalOption(flag string) (bool, bool) {
+		var specs = commandOptionSpecs()
+		if spec := findOptionSpec(specs, flag, true); spec != nil {
+				return true, spec.Value()
+		}
+		if strings.HasPrefix(flag, "--") || len(flag) < 3 {
+				return false, false
+		}
+		var chars = []rune(flag[1:])
+		for j, c := range chars {
+				spec := findOptionSpec(specs, "-"+string(c), true)
+				if spec == nil {
+						return false, false
+				}
+				if spec.Value() {
+						return true, j == len(chars)-1
+				}
+		}
+		return true, false
+}
+
+// 命令行选项 解析结果
+type ParsedOptions struct {
+		values map[string][]string
+		args   []string
+}
+
+func newParsedOptions() *ParsedOptions {
+		var parsed = new(ParsedOptions)
+		parsed.values = make(map[string][]string)
+		return parsed
+}
+
+// 解析命令行选项, 支持:
+// --k=v, --k v, -k v, -kv, 重复选项, 组合短开关 -abc, -- 之后为位置参数
+// strict 时 未知选项 及位置参数 返回错误, 否则 原样保留 (命令模式, 仅识别长选项)
+func ParseOptions(args []string, specs []*OptionSpec, strict bool) (*ParsedOptions, error) {
+		var parsed = newParsedOptions()
+		for i := 0; i < len(args); i++ {
+				var arg = args[i]
+				if arg == "--" {
+						if strict {
+								parsed.args = append(parsed.args, args[i+1:]...)
+						} else {
+								parsed.args = append(parsed.args, args[i:]...)
+						}
+						break
+				}
+				if len(arg) < 2 || arg[0] != '-' {
+						if strict {
+								return nil, fmt.Errorf("unexpected argument %q", arg)
+						}
+						parsed.args = append(parsed.args, arg)
+						continue
+				}
+				var (
+						name, value = arg, ""
+						hasValue    bool
+				)
+				if j := strings.Index(arg, "="); j > 0 {
+						name, value, hasValue = arg[:j], arg[j+1:], true
+				}
+				spec := findOptionSpec(specs, name, strict)
+				if spec == nil && strict && !strings.HasPrefix(arg, "--") {
+						next, err := parsed.bundle(arg, args[i+1:], specs)
+						if err != nil {
+								return nil, err
+						}
+						i += next
+						continue
+				}
+				if spec == nil {
+						if strict {
+								return nil, fmt.Errorf("unknown option %q", name)
+						}
+						parsed.args = append(parsed.args, arg)
+						continue
+				}
+				if !hasValue && spec.Value() {
+						if i+1 >= len(args) || len(args[i+1]) > 1 && args[i+1][0] == '-' {
+								return nil, fmt.Errorf("option %s requires a value", name)
+						}
+						i++
+						value, hasValue = args[i], true
+				}
+				if err := parsed.add(spec, name, value, hasValue); err != nil {
+						return nil, err
+				}
+		}
+		return parsed, nil
+}
+
+// 组合短选项 -abc 或者 -pVALUE, 返回消耗的后续参数个数
+func (this *ParsedOptions) bundle(arg string, rest []string, specs []*OptionSpec) (int, error) {
+		var chars = []rune(arg[1:])
+		for j, c := range chars {
+				var (
+						name = "-" + string(c)
+						spec = findOptionSpec(specs, name, true)
+				)
+				if spec == nil && len(chars) == 1 {
+						return 0, fmt.Errorf("unknown option %q", arg)
+				}
+				if spec == nil {
+						return 0, fmt.Errorf("unknown option %q in %q", name, arg)
+				}
+				if !spec.Value() {
+						if err := this.add(spec, name, "", false); err != nil {
+								return 0, err
+						}
+						continue
+				}
+				if value := strings.TrimPrefix(string(chars[j+1:]), "="); value != "" {
+						return 0, this.add(spec, name, value, true)
+				}
+				if len(rest) == 0 || len(rest[0]) > 1 && rest[0][0] == '-' {
+						return 0, fmt.Errorf("option %s requires a value", name)
+				}
+				return 1, this.add(spec, name, rest[0], true)
+		}
+		return 0, nil
+}
+
+func (this *ParsedOptions) add(spec *OptionSpec, name string, value string, hasValue bool) error {
+		if !hasValue {
+				value = "true"
+		}
+		if _, err := Components.ConvertValue(spec.Type, value); err != nil {
+				return fmt.Errorf("option %s: %v", name, err)
+		}
+		if spec.Multi || !spec.Value() {
+				this.values[spec.Key] = append(this.values[spec.Key], value)
+		} else {
+				this.values[spec.Key] = []string{value}
+		}
+		return nil
+}
+
+// 合并 其他解析结果 的选项及位置参数
+func (this *ParsedOptions) merge(other *ParsedOptions) {
+		for key, values := range other.values {
+				this.values[key] = append(this.values[key], values...)
+		}
+		this.args = append(this.args, other.args...)
+}
+
+func findOptionSpec(specs []*OptionSpec, flag string, shorts bool) *OptionSpec {
+		for _, spec := range specs {
+				if spec.match(flag, shorts) {
+						return spec
+				}
+		}
+		return nil
+}
+
+// 已设置的选项
+func (this *ParsedOptions) Keys() []string {
+		var keys = make([]string, 0, len(this.values))
+		for key := range this.values {
+				keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return keys
+}
+
+// 位置参数, 命令模式下 为未识别的参数
+func (this *ParsedOptions) Args() []string {
+		return this.args
+}
+
+func (this *ParsedOptions) Has(key string) bool {
+		_, ok := this.values[key]
+		return ok
+}
+
+// 选项值, 重复选项 返回最后一个
+func (this *ParsedOptions) String(key string) string {
+		if values := this.values[key]; len(values) > 0 {
+				return values[len(values)-1]
+		}
+		return ""
+}
+
+// 全部选项值, 逗号分隔的值 展开
+func (this *ParsedOptions) Strings(key string) []string {
+		var items []string
+		for _, value := range this.values[key] {
+				for _, it := range strings.Split(value, ",") {
+						if it = strings.TrimSpace(it); it != "" {
+								items = append(items, it)
+						}
+				}
+		}
+		return items
+}
+
+// 开关出现次数, eg: -vvv 为 3
+func (this *ParsedOptions) Count(key string) int {
+		return len(this.values[key])
+}
+
+func (this *ParsedOptions) Bool(key string) bool {
+		v, _ := Components.ConvertValue(Components.BoolValue, this.String(key))
+		b, _ := v.(bool)
+		return b
+}
+
+func (this *ParsedOptions) Int(key string) int {
+		v, _ := Components.ConvertValue(Components.IntValue, this.String(key))
+		n, _ := v.(int)
+		return n
+}
+
+func (this *ParsedOptions) Duration(key string) time.Duration {
+		v, _ := Components.ConvertValue(Components.DurationValue, this.String(key))
+		d, _ := v.(time.Duration)
+		return d
+}
+
+// 帮助信息, 由 optionsMapper 的 @tip/@eg 生成
+func OptionsUsage(out io.Writer) {
+		var (
+				program = filepath.Base(os.Args[0])
+				writer  = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		)
+		fmt.Fprintf(out, "Usage:\n  %s [options]\n  %s <command> [arguments] [--options]\n\nOptions:\n", program, program)
+		for _, spec := range OptionSpecs() {
+				var (
+						shorts []string
+						flags  string
+						extras []string
+				)
+				for _, flag := range spec.Flags {
+						if !strings.HasPrefix(flag, "--") {
+								shorts = append(shorts, flag+", ")
+						}
+				}
+				if flags = strings.Join(shorts, ""); flags == "" {
+						flags = "    "
+				}
+				if long := spec.Long(); long != "" {
+						flags += long
+						if spec.Value() {
+								flags += "=" + strings.ToUpper(strings.TrimPrefix(long, "--"))
+						}
+				}
+				if spec.Value() && spec.Type != Components.StringValue {
+						extras = append(extras, spec.Type)
+				}
+				if spec.Multi {
+						extras = append(extras, "repeatable")
+				}
+				if spec.Example != "" {
+						extras = append(extras, "eg: "+spec.Example)
+				}
+				var tip = spec.Tip
+				if len(extras) > 0 {
+						tip += " (" + strings.Join(extras, ", ") + ")"
+				}
+				fmt.Fprintf(writer, "  %s\t%s\n", flags, tip)
+		}
+		_ = writer.Flush()
+		fmt.Fprintf(out, "\nRun '%s help' to list commands.\n", program)
+}
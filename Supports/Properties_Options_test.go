@@ -0,0 +1,94 @@
+package Supports
+
+import (
+		"bytes"
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"testing"
+		"time"
+)
+
+func TestOptionSpecs(t *testing.T) {
+		Convey("Option Specs Test", t, func() {
+				var specs = make(map[string]*OptionSpec)
+				for _, spec := range OptionSpecs() {
+						specs[spec.Key] = spec
+				}
+				So(specs["paths"].Multi, ShouldBeTrue)
+				So(specs["paths"].Value(), ShouldBeTrue)
+				So(specs["paths"].Tip, ShouldEqual, "配置目录")
+				So(specs["paths"].Example, ShouldEqual, "--paths=/paths,/path2")
+				So(specs["daemon"].Value(), ShouldBeFalse)
+				So(specs["help"].Tip, ShouldEqual, "help show menu")
+
+				var buf bytes.Buffer
+				OptionsUsage(&buf)
+				So(buf.String(), ShouldContainSubstring, "-p, --paths=PATHS")
+				So(buf.String(), ShouldContainSubstring, "配置目录 (repeatable, eg: --paths=/paths,/path2)")
+				So(buf.String(), ShouldNotContainSubstring, "@tip")
+		})
+}
+
+func TestParseOptions(t *testing.T) {
+		var specs = append(OptionSpecs(),
+				&OptionSpec{Key: "port", Flags: []string{"--port"}, Type: Components.IntValue},
+				&OptionSpec{Key: "timeout", Flags: []string{"-t", "--timeout"}, Type: Components.DurationValue},
+		)
+		Convey("Parse Options Test", t, func() {
+				parsed, err := ParseOptions([]string{"-p", "/a", "--paths=/b,/c", "--port", "8080", "-vvv", "-t5s", "--mode", "dev", "--", "-x"}, specs, true)
+				So(err, ShouldBeNil)
+				So(parsed.Strings("paths"), ShouldResemble, []string{"/a", "/b", "/c"})
+				So(parsed.Int("port"), ShouldEqual, 8080)
+				So(parsed.Count("verbose"), ShouldEqual, 3)
+				So(parsed.Bool("verbose"), ShouldBeTrue)
+				So(parsed.Has("version"), ShouldBeFalse)
+				So(parsed.Duration("timeout"), ShouldEqual, 5*time.Second)
+				So(parsed.String("mode"), ShouldEqual, "dev")
+				So(parsed.Args(), ShouldResemble, []string{"-x"})
+				So(parsed.Has("help"), ShouldBeFalse)
+
+				parsed, err = ParseOptions([]string{"--mode=dev", "--mode=test", "-hf", "app.properties", "--daemon=false", "-V"}, specs, true)
+				So(err, ShouldBeNil)
+				So(parsed.Bool("version"), ShouldBeTrue)
+				So(parsed.String("mode"), ShouldEqual, "test")
+				So(parsed.Bool("help"), ShouldBeTrue)
+				So(parsed.String("appFile"), ShouldEqual, "app.properties")
+				So(parsed.Has("daemon"), ShouldBeTrue)
+				So(parsed.Bool("daemon"), ShouldBeFalse)
+
+				var failures = map[string][]string{
+						`unknown option "--quiet"`:       {"--quiet"},
+						`unknown option "-x"`:            {"-x"},
+						`unknown option "-x" in "-vx"`:   {"-vx"},
+						`unexpected argument "start"`:    {"start"},
+						"option --port requires a value": {"--port", "--mode=dev"},
+						`invalid int value "http"`:       {"--port=http"},
+						`invalid duration value "5"`:     {"-t", "5"},
+				}
+				for message, args := range failures {
+						_, err = ParseOptions(args, specs, true)
+						So(err, ShouldNotBeNil)
+						So(err.Error(), ShouldContainSubstring, message)
+				}
+
+				parsed, err = ParseOptions([]string{"tom", "--mode=dev", "-p", "x", "--role", "admin", "-h", "--", "--paths=y"}, commandOptionSpecs(), false)
+				So(err, ShouldBeNil)
+				So(parsed.String("mode"), ShouldEqual, "dev")
+				So(parsed.Has("paths"), ShouldBeFalse)
+				So(parsed.Args(), ShouldResemble, []string{"tom", "-p", "x", "--role", "admin", "-h", "--", "--paths=y"})
+				So(commandArguments([]string{"tom", "--paths", "conf", "--admin"}), ShouldResemble, []string{"tom", "--admin"})
+		})
+}
+
+func TestCommandIndex(t *testing.T) {
+		Convey("Command Index Test", t, func() {
+				So(Components.CommandIndex([]string{"kits", "--mode", "stg", ContainerListCommand}, globalOption), ShouldEqual, 3)
+				So(Components.CommandIndex([]string{"kits", "-m", "stg", "-D", "a=b", ContainerListCommand}, globalOption), ShouldEqual, 5)
+				So(Components.CommandIndex([]string{"kits", "-mstg", "--daemon", ContainerListCommand, "-x"}, globalOption), ShouldEqual, 3)
+				So(Components.CommandIndex([]string{"kits", "--mode=stg", ContainerListCommand}, globalOption), ShouldEqual, 2)
+				So(Components.CommandIndex([]string{"kits", "--bogus", ContainerListCommand}, globalOption), ShouldEqual, 0)
+				So(Components.CommandIndex([]string{"kits", "-h", ContainerListCommand}, globalOption), ShouldEqual, 0)
+				So(Components.CommandIndex([]string{"kits", "--", ContainerListCommand}, globalOption), ShouldEqual, 0)
+				So(Components.CommandIndex([]string{"kits", "--mode"}, globalOption), ShouldEqual, 0)
+		})
+}
@@ -0,0 +1,42 @@
+package Supports
+
+import (
+		. "github.com/smartystreets/goconvey/convey"
+		"github.com/webGameLinux/kits/Components"
+		"github.com/webGameLinux/kits/Contracts"
+		"testing"
+)
+
+func TestPropertiesOverrides(t *testing.T) {
+		Convey("Properties Overrides Test", t, func() {
+				parsed, err := ParseOptions([]string{"--set", "http.port=9090", "-Dredis.addr=127.0.0.1:6380", "--set=http.port=9191", "-D", "game.tags=a=b"}, OptionSpecs(), true)
+				So(err, ShouldBeNil)
+				overrides, err := parseOverrides(parsed.values[setOptionKey])
+				So(err, ShouldBeNil)
+				So(overrides, ShouldResemble, map[string]string{
+						"http.port":  "9191",
+						"redis.addr": "127.0.0.1:6380",
+						"game.tags":  "a=b",
+				})
+
+				_, err = parseOverrides([]string{"http.port"})
+				So(err, ShouldNotBeNil)
+				_, err = parseOverrides([]string{"=9090"})
+				So(err, ShouldNotBeNil)
+
+				var props = &Properties{overrides: overrides}
+				So(props.With(setOptionKey), ShouldEqual, Contracts.AppConfigOverrides)
+				So(props.Get(Contracts.AppConfigOverrides), ShouldResemble, overrides)
+				props.Overrides()["http.port"] = "1"
+				So(props.Overrides()["http.port"], ShouldEqual, "9191")
+
+				parsed, err = ParseOptions([]string{"-vv"}, OptionSpecs(), true)
+				So(err, ShouldBeNil)
+				props.parsed = parsed
+				So(props.With("verbose"), ShouldEqual, Contracts.AppVerbose)
+				So(props.Get(Contracts.AppVerbose), ShouldEqual, 2)
+				So(Components.VerboseLevel(props.Get(Contracts.AppVerbose).(int)), ShouldEqual, "trace")
+				So(Components.VerboseLevel(1), ShouldEqual, "debug")
+				So(Components.VerboseLevel(0), ShouldEqual, "")
+		})
+}
@@ -14,9 +14,12 @@ require (
 	github.com/flosch/pongo2 v0.0.0-20200529170236-5abacdfa4915 // indirect
 	github.com/gavv/monotime v0.0.0-20190418164738-30dba4353424 // indirect
 	github.com/go-redis/redis/v8 v8.0.0-beta.5
+	github.com/google/martian v2.1.0+incompatible
 	github.com/google/uuid v1.1.1 // indirect
-	github.com/googollee/go-socket.io v1.4.3 // indirect
+	github.com/googollee/go-engine.io v1.4.3-0.20200220091802-9b2ab104b298
+	github.com/googollee/go-socket.io v1.4.3
 	github.com/gorilla/schema v1.1.0 // indirect
+	github.com/gorilla/websocket v1.4.2
 	github.com/hashicorp/go-uuid v1.0.1
 	github.com/imkira/go-interpol v1.1.0 // indirect
 	github.com/iris-contrib/blackfriday v2.0.0+incompatible // indirect
@@ -31,7 +34,7 @@ require (
 	github.com/microcosm-cc/bluemonday v1.0.2 // indirect
 	github.com/mitchellh/mapstructure v1.3.2
 	github.com/moul/http2curl v1.0.0 // indirect
-	github.com/nats-io/nats.go v1.10.0 // indirect
+	github.com/nats-io/nats.go v1.10.0
 	github.com/prometheus/common v0.10.0
 	github.com/sergi/go-diff v1.1.0 // indirect
 	github.com/shiena/ansicolor v0.0.0-20151119151921-a422bbe96644 // indirect
@@ -1,14 +1,22 @@
 package main
 
 import (
+		"github.com/webGameLinux/kits/Components"
 		. "github.com/webGameLinux/kits/Functions"
+		"os"
 )
 
 func main() {
 		// 获取应用
 		var app = AppContainer()
-		// 引导加载
-		Bootstrap(app)
+		// 引导加载, 命令行参数错误 已输出用法
+		if err := Bootstrap(app); err != nil {
+				os.Exit(Components.ExitUsage)
+		}
+		// 控制台命令, 执行后退出
+		if code, ok := Console(app); ok {
+				os.Exit(code)
+		}
 		// 启动应用
 		app.StarUp()
 }